@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/metinorak/goenv"
+)
+
+// problem is an issue found by goenv check
+type problem struct {
+	// Kind is missing, invalid, unresolved, validation or unknown
+	Kind    string `json:"kind"`
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+type checkReport struct {
+	Valid    bool      `json:"valid"`
+	Problems []problem `json:"problems"`
+}
+
+func runCheck(args []string) int {
+	var files filesFlag
+
+	fs := flag.NewFlagSet("goenv check", flag.ContinueOnError)
+	typeSpec := fs.String("type", "", "config struct given as dir.Name, like ./internal/config.Config")
+	fs.Var(&files, "f", "dotenv file to check, can be repeated with later files taking precedence (default .env)")
+	jsonOutput := fs.Bool("json", false, "write the report as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: goenv check -type dir.Name [-f file]... [-json]")
+		fmt.Fprintln(fs.Output(), "exits with 1 when problems are found and 2 when the check cannot run")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *typeSpec == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+	if len(files) == 0 {
+		files = filesFlag{".env"}
+	}
+
+	structType, err := loadStructType(*typeSpec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv check: %s\n", err)
+		return 2
+	}
+
+	values, err := readDotenvFiles(files)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv check: %s\n", err)
+		return 2
+	}
+
+	problems, err := checkValues(structType, values)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv check: %s\n", err)
+		return 2
+	}
+
+	if err := writeCheckReport(os.Stdout, problems, *jsonOutput); err != nil {
+		fmt.Fprintf(os.Stderr, "goenv check: %s\n", err)
+		return 2
+	}
+
+	if len(problems) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// readDotenvFiles merges the variables of the files, later files taking
+// precedence
+func readDotenvFiles(files []string) (goenv.MapReader, error) {
+	values := make(goenv.MapReader)
+	for _, file := range files {
+		reader, err := goenv.NewDotenvReader(file)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range reader {
+			values[key] = value
+		}
+	}
+
+	return values, nil
+}
+
+// checkValues loads the values into a new value of the struct type and
+// returns the problems of every field, and the keys no field is loaded from
+func checkValues(structType reflect.Type, values goenv.MapReader) ([]problem, error) {
+	fields, err := goenv.Describe(reflect.New(structType).Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	loader, err := goenv.NewLoader(goenv.WithReader(values))
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []problem{}
+	for _, err := range loader.Check(reflect.New(structType).Interface()) {
+		problems = append(problems, newProblem(err))
+	}
+
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.Key] = true
+	}
+
+	var unknown []string
+	for key := range values {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+
+	for _, key := range unknown {
+		problems = append(problems, problem{
+			Kind:    "unknown",
+			Key:     key,
+			Message: fmt.Sprintf("no field is loaded from %s", key),
+		})
+	}
+
+	return problems, nil
+}
+
+func newProblem(err error) problem {
+	var requiredErr *goenv.ErrRequiredEnvValue
+	var parseErr *goenv.ErrParseEnvValue
+	var resolveErr *goenv.ErrResolveEnvValue
+	var validationErr *goenv.ErrValidation
+
+	switch {
+	case errors.As(err, &requiredErr):
+		return problem{Kind: "missing", Key: requiredErr.Key, Message: err.Error()}
+	case errors.As(err, &parseErr):
+		return problem{Kind: "invalid", Key: parseErr.Key, Message: err.Error()}
+	case errors.As(err, &resolveErr):
+		return problem{Kind: "unresolved", Key: resolveErr.Key, Message: err.Error()}
+	case errors.As(err, &validationErr):
+		return problem{Kind: "validation", Key: validationErr.Key, Message: err.Error()}
+	default:
+		return problem{Kind: "invalid", Message: err.Error()}
+	}
+}
+
+func writeCheckReport(w io.Writer, problems []problem, asJSON bool) error {
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(checkReport{
+			Valid:    len(problems) == 0,
+			Problems: problems,
+		})
+	}
+
+	for _, p := range problems {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", p.Kind, p.Key, p.Message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
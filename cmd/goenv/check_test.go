@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/metinorak/goenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const configSource = `package config
+
+import "time"
+
+type Level string
+
+type DBConfig struct {
+	Host string ` + "`required:\"true\"`" + `
+	Port int    ` + "`default:\"5432\" validate:\"max=65535\"`" + `
+}
+
+type Config struct {
+	LogLevel Level ` + "`validate:\"oneof=debug info\"`" + `
+	Timeout  time.Duration
+	Hosts    []string
+	Database DBConfig ` + "`env:\"DB\"`" + `
+	internal string
+}
+`
+
+func writeConfigPackage(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.go"), []byte(configSource), 0o600))
+
+	return dir
+}
+
+func TestLoadStructType(t *testing.T) {
+	dir := writeConfigPackage(t)
+
+	structType, err := loadStructType(dir + ".Config")
+	require.NoError(t, err)
+
+	fields, err := goenv.Describe(reflect.New(structType).Interface())
+	require.NoError(t, err)
+
+	var keys []string
+	for _, field := range fields {
+		keys = append(keys, field.Key)
+	}
+	assert.Equal(t, []string{"LOG_LEVEL", "TIMEOUT", "HOSTS", "DB_HOST", "DB_PORT"}, keys)
+	assert.Equal(t, durationType, fields[1].Type)
+
+	_, err = loadStructType(dir + ".Level")
+	assert.Error(t, err)
+
+	_, err = loadStructType(dir + ".Missing")
+	assert.Error(t, err)
+}
+
+func TestCheckValues(t *testing.T) {
+	structType, err := loadStructType(writeConfigPackage(t) + ".Config")
+	require.NoError(t, err)
+
+	problems, err := checkValues(structType, goenv.MapReader{
+		"LOG_LEVEL": "trace",
+		"TIMEOUT":   "soon",
+		"DB_PORT":   "70000",
+		"DB_USER":   "admin",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"validation", "invalid", "missing", "validation", "unknown"}, problemKinds(problems))
+	assert.Equal(t, "DB_USER", problems[4].Key)
+
+	problems, err = checkValues(structType, goenv.MapReader{
+		"TIMEOUT": time.Second.String(),
+		"DB_HOST": "localhost",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func problemKinds(problems []problem) []string {
+	kinds := make([]string, len(problems))
+	for i, p := range problems {
+		kinds[i] = p.Kind
+	}
+
+	return kinds
+}
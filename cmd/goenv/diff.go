@@ -0,0 +1,180 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/metinorak/goenv"
+)
+
+// secretKeyPattern matches the keys that are masked even when no struct
+// tags them as secret
+var secretKeyPattern = regexp.MustCompile(`(?i)(PASSWORD|PASSWD|SECRET|TOKEN|PRIVATE|CREDENTIAL|API_?KEY)`)
+
+const maskedValue = "***"
+
+// envChange is a key whose value differs between two environments
+type envChange struct {
+	key string
+
+	// op is + for added, - for removed and ~ for changed keys
+	op       byte
+	oldValue string
+	newValue string
+}
+
+func runDiff(args []string) int {
+	fs := flag.NewFlagSet("goenv diff", flag.ContinueOnError)
+	typeSpec := fs.String("type", "", "only compare the keys of a config struct given as dir.Name, like ./internal/config.Config")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: goenv diff [-type dir.Name] old new")
+		fmt.Fprintln(fs.Output(), "old and new are dotenv files, or - for the environment of goenv")
+		fmt.Fprintln(fs.Output(), "exits with 1 when they differ and 2 when they cannot be compared")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+
+	var fields []goenv.FieldInfo
+	if *typeSpec != "" {
+		structType, err := loadStructType(*typeSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "goenv diff: %s\n", err)
+			return 2
+		}
+
+		fields, err = goenv.Describe(reflect.New(structType).Interface())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "goenv diff: %s\n", err)
+			return 2
+		}
+		if fields == nil {
+			fields = []goenv.FieldInfo{}
+		}
+	}
+
+	oldValues, err := readEnvSource(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv diff: %s\n", err)
+		return 2
+	}
+
+	newValues, err := readEnvSource(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv diff: %s\n", err)
+		return 2
+	}
+
+	changes := diffEnv(oldValues, newValues, fields)
+	if err := writeDiff(os.Stdout, changes); err != nil {
+		fmt.Fprintf(os.Stderr, "goenv diff: %s\n", err)
+		return 2
+	}
+
+	if len(changes) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// readEnvSource reads a dotenv file, or the environment of the process when
+// the name is -
+func readEnvSource(name string) (goenv.MapReader, error) {
+	if name == "-" {
+		values := make(goenv.MapReader)
+		for _, kv := range os.Environ() {
+			if key, value, ok := strings.Cut(kv, "="); ok {
+				values[key] = value
+			}
+		}
+		return values, nil
+	}
+
+	return goenv.NewDotenvReader(name)
+}
+
+// diffEnv returns the keys that differ between the environments sorted by
+// key, with the values of secrets masked. When fields are given, only their
+// keys are compared and the fields tagged secret:"true" are masked too.
+func diffEnv(oldValues, newValues map[string]string, fields []goenv.FieldInfo) []envChange {
+	secrets := make(map[string]bool)
+	keys := make(map[string]bool)
+	for _, field := range fields {
+		keys[field.Key] = true
+		secrets[field.Key] = field.Secret
+	}
+	if fields == nil {
+		for key := range oldValues {
+			keys[key] = true
+		}
+		for key := range newValues {
+			keys[key] = true
+		}
+	}
+
+	var changes []envChange
+	for key := range keys {
+		oldValue, inOld := oldValues[key]
+		newValue, inNew := newValues[key]
+
+		var op byte
+		switch {
+		case inOld && !inNew:
+			op = '-'
+		case !inOld && inNew:
+			op = '+'
+		case inOld && inNew && oldValue != newValue:
+			op = '~'
+		default:
+			continue
+		}
+
+		if secrets[key] || secretKeyPattern.MatchString(key) {
+			oldValue, newValue = maskedValue, maskedValue
+		}
+
+		changes = append(changes, envChange{
+			key:      key,
+			op:       op,
+			oldValue: oldValue,
+			newValue: newValue,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].key < changes[j].key
+	})
+
+	return changes
+}
+
+func writeDiff(w io.Writer, changes []envChange) error {
+	for _, change := range changes {
+		var err error
+		switch change.op {
+		case '+':
+			_, err = fmt.Fprintf(w, "+ %s=%s\n", change.key, change.newValue)
+		case '-':
+			_, err = fmt.Fprintf(w, "- %s=%s\n", change.key, change.oldValue)
+		default:
+			_, err = fmt.Fprintf(w, "~ %s=%s -> %s\n", change.key, change.oldValue, change.newValue)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
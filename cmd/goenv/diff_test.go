@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/metinorak/goenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffEnv(t *testing.T) {
+	staging := map[string]string{
+		"HOST":        "staging.internal",
+		"PORT":        "8080",
+		"DB_PASSWORD": "staging-secret",
+		"SIGNING":     "a",
+		"DEBUG":       "true",
+	}
+	prod := map[string]string{
+		"HOST":        "prod.internal",
+		"PORT":        "8080",
+		"DB_PASSWORD": "prod-secret",
+		"SIGNING":     "b",
+		"REPLICAS":    "3",
+	}
+
+	t.Run("TestDiffEnv_WithAllKeys", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeDiff(&buf, diffEnv(staging, prod, nil)))
+
+		assert.Equal(t, `~ DB_PASSWORD=*** -> ***
+- DEBUG=true
+~ HOST=staging.internal -> prod.internal
++ REPLICAS=3
+~ SIGNING=a -> b
+`, buf.String())
+	})
+
+	t.Run("TestDiffEnv_WithFields", func(t *testing.T) {
+		fields := []goenv.FieldInfo{
+			{Key: "HOST"},
+			{Key: "PORT"},
+			{Key: "SIGNING", Secret: true},
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, writeDiff(&buf, diffEnv(staging, prod, fields)))
+
+		assert.Equal(t, `~ HOST=staging.internal -> prod.internal
+~ SIGNING=*** -> ***
+`, buf.String())
+	})
+
+	t.Run("TestDiffEnv_WhenEqual", func(t *testing.T) {
+		assert.Empty(t, diffEnv(prod, prod, nil))
+	})
+}
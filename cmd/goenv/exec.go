@@ -0,0 +1,205 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/metinorak/goenv"
+	"github.com/metinorak/goenv/internal/dotenv"
+)
+
+// filesFlag collects the values of a flag that can be repeated
+type filesFlag []string
+
+func (f *filesFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *filesFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// negatedFlag is a boolean flag setting the opposite of its value to
+// another flag, like -no-override for -override
+type negatedFlag struct {
+	value *bool
+}
+
+func (f negatedFlag) String() string {
+	if f.value == nil {
+		return "false"
+	}
+	return strconv.FormatBool(!*f.value)
+}
+
+func (f negatedFlag) Set(value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+
+	*f.value = !b
+	return nil
+}
+
+func (f negatedFlag) IsBoolFlag() bool {
+	return true
+}
+
+func runExec(args []string) int {
+	var files filesFlag
+	var override bool
+
+	fs := flag.NewFlagSet("goenv exec", flag.ContinueOnError)
+	fs.Var(&files, "f", "dotenv file to load, can be repeated with later files taking precedence (default .env)")
+	fs.BoolVar(&override, "override", false, "let the files override variables that are already set")
+	fs.Var(negatedFlag{&override}, "no-override", "keep variables that are already set (default)")
+	expand := fs.Bool("expand", true, "expand $VAR and ${VAR} in the values of the files")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: goenv exec [-f file]... [-override | -no-override] [-expand=false] -- command [args...]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	if len(files) == 0 {
+		files = filesFlag{".env"}
+	}
+
+	env, err := execEnv(files, override, *expand, os.Environ())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv exec: %s\n", err)
+		return 1
+	}
+
+	path, err := exec.LookPath(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv exec: %s\n", err)
+		return 127
+	}
+
+	return execCommand(path, fs.Args(), env)
+}
+
+// execEnv returns the environment of the command, merging the variables of
+// the files into environ
+func execEnv(files []string, override bool, expand bool, environ []string) ([]string, error) {
+	current := make(goenv.MapReader)
+	for _, kv := range environ {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			current[key] = value
+		}
+	}
+
+	// later files take precedence, so they come first in the chain
+	fileReaders := make([]goenv.EnvReader, 0, len(files))
+	fileKeys := make(map[string]bool)
+	literals := make(map[string]bool)
+	for _, file := range files {
+		lines, err := readDotenvLines(file)
+		if err != nil {
+			return nil, err
+		}
+
+		reader := make(goenv.MapReader)
+		for _, line := range lines {
+			if line.Key == "" {
+				continue
+			}
+
+			reader[line.Key] = line.Value
+			fileKeys[line.Key] = true
+			literals[line.Key] = line.Literal
+		}
+		fileReaders = append([]goenv.EnvReader{reader}, fileReaders...)
+	}
+
+	var readers []goenv.EnvReader
+	if override {
+		readers = append(fileReaders, current)
+	} else {
+		readers = append([]goenv.EnvReader{current}, fileReaders...)
+	}
+	chain := goenv.NewChainReader(readers...)
+
+	merged := make(map[string]string, len(current)+len(fileKeys))
+	for key, value := range current {
+		merged[key] = value
+	}
+
+	expander := &envExpander{
+		chain:     chain,
+		current:   current,
+		fileKeys:  fileKeys,
+		literals:  literals,
+		override:  override,
+		expanding: make(map[string]bool),
+	}
+	for key := range fileKeys {
+		if expand {
+			merged[key] = expander.lookup(key)
+		} else {
+			merged[key], _ = chain.LookupEnv(key)
+		}
+	}
+
+	return goenv.MapReader(merged).Environ(), nil
+}
+
+// readDotenvLines reads the lines of a dotenv file, which tell the single
+// quoted values apart unlike goenv.NewDotenvReader
+func readDotenvLines(path string) ([]dotenv.Line, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := dotenv.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return lines, nil
+}
+
+// envExpander expands the references in the values coming from the files to
+// the merged values of the variables they name. Values single quoted in the
+// file they are taken from are literals and left as they are.
+type envExpander struct {
+	chain     *goenv.ChainReader
+	current   goenv.MapReader
+	fileKeys  map[string]bool
+	literals  map[string]bool
+	override  bool
+	expanding map[string]bool
+}
+
+func (e *envExpander) lookup(key string) string {
+	// a reference to a variable being expanded, like PATH in
+	// PATH=$PATH:/usr/local/bin, is to its value before the files
+	if e.expanding[key] {
+		return e.current[key]
+	}
+
+	value, _ := e.chain.LookupEnv(key)
+
+	_, isSet := e.current[key]
+	if !e.fileKeys[key] || isSet && !e.override || e.literals[key] {
+		return value
+	}
+
+	e.expanding[key] = true
+	defer delete(e.expanding, key)
+
+	return os.Expand(value, e.lookup)
+}
@@ -0,0 +1,18 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// execCommand replaces the process with the command, so that it receives
+// the signals sent to goenv directly
+func execCommand(path string, args []string, env []string) int {
+	err := syscall.Exec(path, args, env)
+
+	fmt.Fprintf(os.Stderr, "goenv exec: %s\n", err)
+	return 126
+}
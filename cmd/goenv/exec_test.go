@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecEnv(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, ".env")
+	local := filepath.Join(dir, ".env.local")
+	require.NoError(t, os.WriteFile(base, []byte("HOST=localhost\nURL=http://${HOST}:$PORT\nPORT=8080\nPATH=$PATH:/opt/bin\nDB_PASSWORD='pa$word'\n"), 0o600))
+	require.NoError(t, os.WriteFile(local, []byte("PORT=9090\nHOME=/tmp\n"), 0o600))
+
+	environ := []string{"PATH=/usr/bin", "HOME=/root"}
+
+	t.Run("TestExecEnv_WithoutOverride", func(t *testing.T) {
+		env, err := execEnv([]string{base, local}, false, true, environ)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"DB_PASSWORD=pa$word",
+			"HOME=/root",
+			"HOST=localhost",
+			"PATH=/usr/bin",
+			"PORT=9090",
+			"URL=http://localhost:9090",
+		}, env)
+	})
+
+	t.Run("TestExecEnv_WithOverride", func(t *testing.T) {
+		env, err := execEnv([]string{base, local}, true, true, environ)
+		require.NoError(t, err)
+
+		assert.Contains(t, env, "HOME=/tmp")
+		assert.Contains(t, env, "PATH=/usr/bin:/opt/bin")
+	})
+
+	t.Run("TestExecEnv_WhenLiteralValueIsOverridden", func(t *testing.T) {
+		override := filepath.Join(dir, ".env.override")
+		require.NoError(t, os.WriteFile(override, []byte("DB_PASSWORD=$HOST-$word\n"), 0o600))
+
+		env, err := execEnv([]string{base, override}, false, true, environ)
+		require.NoError(t, err)
+
+		assert.Contains(t, env, "DB_PASSWORD=localhost-")
+	})
+
+	t.Run("TestExecEnv_WithoutExpansion", func(t *testing.T) {
+		env, err := execEnv([]string{base}, false, false, environ)
+		require.NoError(t, err)
+
+		assert.Contains(t, env, "URL=http://${HOST}:$PORT")
+	})
+
+	t.Run("TestExecEnv_WhenFileIsMissing", func(t *testing.T) {
+		_, err := execEnv([]string{filepath.Join(dir, "missing")}, false, true, environ)
+		assert.Error(t, err)
+	})
+}
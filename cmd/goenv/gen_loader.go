@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/metinorak/goenv"
+)
+
+const goenvImportPath = "github.com/metinorak/goenv"
+
+// loaderField is a field of the struct to generate the loading code of
+type loaderField struct {
+	goenv.FieldInfo
+
+	// typ is the type of the field in the package of the struct
+	typ     types.Type
+	resolve bool
+	rules   string
+
+	// rulesVar is the package variable holding the parsed rules
+	rulesVar string
+}
+
+func runGenLoader(args []string) int {
+	fs := flag.NewFlagSet("goenv gen-loader", flag.ContinueOnError)
+	typeName := fs.String("type", "", "name of the config struct")
+	dir := fs.String("dir", ".", "directory of the package of the struct")
+	output := fs.String("o", "", "file to write, by default <type>_goenv.go in the directory of the package")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: goenv gen-loader -type Name [-dir dir] [-o file]")
+		fmt.Fprintln(fs.Output(), "generates a Load<Name>(goenv.EnvReader) function loading the struct like goenv.Load, without reflection")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *typeName == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	source, err := genLoader(*dir, *typeName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv gen-loader: %s\n", err)
+		return 1
+	}
+
+	path := *output
+	if path == "" {
+		path = filepath.Join(*dir, strings.ToLower(*typeName)+"_goenv.go")
+	}
+	if err := os.WriteFile(path, source, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "goenv gen-loader: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// genLoader returns the gofmt-formatted source of a function loading the
+// struct from an EnvReader with the same keys, defaults, references,
+// parsing, validation and errors as goenv.Load
+func genLoader(dir string, typeName string) ([]byte, error) {
+	structType, pkg, err := checkStructType(dir + "." + typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the keys, defaults and flags come from Describe, so that they are
+	// named exactly as goenv.Load names them
+	infos, err := goenv.Describe(reflect.New(reflectStruct(structType)).Interface())
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("type %s has no fields to load", typeName)
+	}
+
+	fields := make([]loaderField, len(infos))
+	for i, info := range infos {
+		v, tag := lookupFieldPath(structType, info.Path)
+		fields[i] = loaderField{
+			FieldInfo: info,
+			typ:       v.Type(),
+			resolve:   tag.Get("resolve") == "true",
+			rules:     tag.Get("validate"),
+		}
+
+		// the rules are parsed once, when the package is initialized
+		if fields[i].rules != "" {
+			fields[i].rulesVar = strings.ToLower(typeName[:1]) + typeName[1:] + strings.ReplaceAll(info.Path, ".", "") + "Rules"
+		}
+	}
+
+	imports := map[string]bool{"fmt": true, goenvImportPath: true}
+	qualifier := func(other *types.Package) string {
+		if other == pkg {
+			return ""
+		}
+
+		imports[other.Path()] = true
+		return other.Name()
+	}
+
+	var body bytes.Buffer
+	usesOk := false
+	for _, field := range fields {
+		if err := writeFieldLoader(&body, field, typeName, qualifier, imports); err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Path, err)
+		}
+		usesOk = usesOk || field.Required || field.Default != ""
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by goenv gen-loader. DO NOT EDIT.\n\npackage %s\n\n", pkg.Name())
+
+	// the standard library comes first, then the other packages
+	var std, other []string
+	for path := range imports {
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	buf.WriteString("import (\n")
+	for _, path := range std {
+		fmt.Fprintf(&buf, "%q\n", path)
+	}
+	buf.WriteString("\n")
+	for _, path := range other {
+		fmt.Fprintf(&buf, "%q\n", path)
+	}
+	buf.WriteString(")\n\n")
+
+	var rulesVars bytes.Buffer
+	for _, field := range fields {
+		if field.rulesVar != "" {
+			fmt.Fprintf(&rulesVars, "%s = goenv.MustCompileValidationRules(%q)\n", field.rulesVar, field.rules)
+		}
+	}
+	if rulesVars.Len() > 0 {
+		fmt.Fprintf(&buf, "// Validation rules of the fields of %s, parsed once\nvar (\n", typeName)
+		buf.Write(rulesVars.Bytes())
+		buf.WriteString(")\n\n")
+	}
+
+	fmt.Fprintf(&buf, "// Load%s loads a %s from the reader like goenv.Load, without reflection\n", typeName, typeName)
+	fmt.Fprintf(&buf, "func Load%s(r goenv.EnvReader) (%s, error) {\n", typeName, typeName)
+	buf.WriteString(`lookup := func(key string) (string, bool, error) {
+		if checked, ok := r.(goenv.CheckedEnvReader); ok {
+			return checked.LookupEnvChecked(key)
+		}
+
+		value, ok := r.LookupEnv(key)
+		return value, ok, nil
+	}
+
+`)
+	fmt.Fprintf(&buf, "var c %s\nvar v string\nvar err error\n", typeName)
+	if usesOk {
+		buf.WriteString("var ok bool\n")
+	}
+	buf.WriteString("\n")
+	buf.Write(body.Bytes())
+	buf.WriteString("return c, nil\n}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// lookupFieldPath returns the field at the path, like Database.Host, of the
+// struct, with its tag
+func lookupFieldPath(structType *types.Struct, path string) (*types.Var, reflect.StructTag) {
+	var field *types.Var
+	var tag reflect.StructTag
+	for _, name := range strings.Split(path, ".") {
+		for i := 0; i < structType.NumFields(); i++ {
+			if structType.Field(i).Name() == name {
+				field, tag = structType.Field(i), reflect.StructTag(structType.Tag(i))
+				break
+			}
+		}
+
+		if next, ok := field.Type().Underlying().(*types.Struct); ok {
+			structType = next
+		}
+	}
+
+	return field, tag
+}
+
+// writeFieldLoader writes the statements loading the field, mirroring the
+// steps of goenv.Load
+func writeFieldLoader(w *bytes.Buffer, field loaderField, typeName string, qualifier types.Qualifier, imports map[string]bool) error {
+	zero := typeName + "{}"
+	key := fmt.Sprintf("%q", field.Key)
+
+	fmt.Fprintf(w, "// %s\n", field.Path)
+	if field.Required || field.Default != "" {
+		fmt.Fprintf(w, "v, ok, err = lookup(%s)\n", key)
+	} else {
+		fmt.Fprintf(w, "v, _, err = lookup(%s)\n", key)
+	}
+	fmt.Fprintf(w, "if err != nil {\nreturn %s, fmt.Errorf(\"failed to read environment variable %%s: %%w\", %s, err)\n}\n", zero, key)
+
+	if field.Required {
+		fmt.Fprintf(w, "if !ok {\nreturn %s, &goenv.ErrRequiredEnvValue{Key: %s}\n}\n", zero, key)
+	}
+	if field.Default != "" {
+		fmt.Fprintf(w, "if !ok {\nv = %q\n}\n", field.Default)
+	}
+	if field.resolve {
+		fmt.Fprintf(w, "if v != \"\" {\nif v, err = goenv.Resolve(r, v); err != nil {\nreturn %s, &goenv.ErrResolveEnvValue{Key: %s, Err: err}\n}\n}\n", zero, key)
+	}
+
+	parseErr := fmt.Sprintf("return %s, &goenv.ErrParseEnvValue{Key: %s, Value: v}", zero, key)
+	target := "c." + field.Path
+	typeExpr := types.TypeString(field.typ, qualifier)
+
+	w.WriteString("if v != \"\" {\n")
+	if field.Type == durationType {
+		imports["time"] = true
+		fmt.Fprintf(w, "parsed, err := time.ParseDuration(v)\nif err != nil {\n%s\n}\n%s = parsed\n", parseErr, target)
+	} else {
+		switch underlying := field.typ.Underlying().(type) {
+		case *types.Basic:
+			parse, ok := basicParsers[underlying.Kind()]
+			if !ok {
+				return fmt.Errorf("type %s is not loaded by goenv", typeExpr)
+			}
+			// named types, like type Level string, need a conversion
+			value := "parsed"
+			if parse == "" {
+				value = "v"
+			}
+			if !types.Identical(field.typ, underlying) {
+				value = fmt.Sprintf("%s(%s)", typeExpr, value)
+			}
+
+			if parse != "" {
+				imports["strconv"] = true
+				fmt.Fprintf(w, "parsed, err := %s\nif err != nil {\n%s\n}\n", parse, parseErr)
+			}
+			fmt.Fprintf(w, "%s = %s\n", target, value)
+
+		case *types.Slice:
+			if !types.Identical(underlying.Elem(), types.Typ[types.String]) {
+				return fmt.Errorf("type %s is not loaded by goenv, slices must be []string", typeExpr)
+			}
+			imports["strings"] = true
+			fmt.Fprintf(w, "%s = strings.Split(v, \",\")\n", target)
+
+		case *types.Map:
+			elem, ok := underlying.Elem().(*types.Basic)
+			if !ok || !types.Identical(underlying.Key(), types.Typ[types.String]) {
+				return fmt.Errorf("type %s is not loaded by goenv, maps must be map[string]T with a basic T", typeExpr)
+			}
+			parse, ok := basicParsers[elem.Kind()]
+			if !ok {
+				return fmt.Errorf("type %s is not loaded by goenv", typeExpr)
+			}
+
+			imports["strings"] = true
+			fmt.Fprintf(w, "m := make(%s)\nfor _, pair := range strings.Split(v, \",\") {\n", typeExpr)
+			fmt.Fprintf(w, "kv := strings.Split(pair, \":\")\nif len(kv) != 2 {\n%s\n}\n", parseErr)
+			if parse != "" {
+				imports["strconv"] = true
+				parse = strings.ReplaceAll(parse, "(v", "(kv[1]")
+				fmt.Fprintf(w, "parsed, err := %s\nif err != nil {\n%s\n}\nm[kv[0]] = parsed\n", parse, parseErr)
+			} else {
+				w.WriteString("m[kv[0]] = kv[1]\n")
+			}
+			fmt.Fprintf(w, "}\n%s = m\n", target)
+
+		default:
+			return fmt.Errorf("type %s is not loaded by goenv", typeExpr)
+		}
+	}
+
+	if field.rulesVar != "" {
+		fmt.Fprintf(w, "if err := %s.Validate(%s, %s); err != nil {\nreturn %s, err\n}\n", field.rulesVar, key, target, zero)
+	}
+	w.WriteString("}\n\n")
+
+	return nil
+}
+
+// basicParsers are the expressions parsing v into the basic types goenv
+// loads, empty for strings
+var basicParsers = map[types.BasicKind]string{
+	types.String:  "",
+	types.Int:     "strconv.Atoi(v)",
+	types.Float64: "strconv.ParseFloat(v, 64)",
+	types.Bool:    "strconv.ParseBool(v)",
+}
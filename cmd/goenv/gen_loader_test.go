@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenLoader(t *testing.T) {
+	t.Run("TestGenLoader_MatchesGeneratedFile", func(t *testing.T) {
+		dir := filepath.Join("..", "..", "internal", "loadertest")
+
+		source, err := genLoader(dir, "Config")
+		require.NoError(t, err)
+
+		// internal/loadertest checks that the generated loader agrees with
+		// goenv, so it must be regenerated when the generator changes
+		assert.Equal(t, string(mustReadFile(t, filepath.Join(dir, "config_goenv.go"))), string(source),
+			"run go generate ./internal/loadertest")
+	})
+
+	t.Run("TestGenLoader_WhenTypeIsNotLoaded", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.go"), []byte("package config\n\ntype Config struct {\n\tPorts []int\n}\n"), 0o600))
+
+		_, err := genLoader(dir, "Config")
+		assert.Error(t, err)
+	})
+}
+
+func mustReadFile(t *testing.T, path string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return data
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/metinorak/goenv"
+)
+
+// initialisms are the key segments written in upper case in field names
+var initialisms = map[string]bool{
+	"API": true, "AWS": true, "CPU": true, "DB": true, "DNS": true, "GRPC": true,
+	"HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true, "JWT": true,
+	"SQL": true, "SSL": true, "TCP": true, "TLS": true, "TTL": true, "UDP": true,
+	"UI": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// urlRule is the validation rule of the fields whose example is a URL
+const urlRule = `regex=^[a-zA-Z][a-zA-Z0-9+.-]*://`
+
+// envEntry is a variable of the example file, with the segments of its key
+// left to place in nested structs
+type envEntry struct {
+	segments []string
+	value    string
+}
+
+// structDef is a struct type to generate
+type structDef struct {
+	name   string
+	fields []string
+}
+
+func runGenStruct(args []string) int {
+	fs := flag.NewFlagSet("goenv gen-struct", flag.ContinueOnError)
+	file := fs.String("f", ".env.example", "dotenv file to generate the struct from")
+	pkg := fs.String("pkg", "config", "package of the generated file")
+	typeName := fs.String("type", "Config", "name of the generated struct")
+	output := fs.String("o", "", "file to write to instead of the standard output")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: goenv gen-struct [-f file] [-pkg name] [-type name] [-o file]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	values, err := goenv.NewDotenvReader(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv gen-struct: %s\n", err)
+		return 1
+	}
+
+	source, err := genStruct(values, *file, *pkg, *typeName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv gen-struct: %s\n", err)
+		return 1
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(source)
+	} else {
+		err = os.WriteFile(*output, source, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv gen-struct: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// genStruct returns the gofmt-formatted source of a struct loading the
+// variables, grouping the keys sharing a prefix into nested structs
+func genStruct(values map[string]string, file string, pkg string, typeName string) ([]byte, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]envEntry, len(keys))
+	for i, key := range keys {
+		entries[i] = envEntry{segments: strings.Split(key, "_"), value: values[key]}
+	}
+
+	var defs []structDef
+	usesTime := buildStruct(typeName, "", entries, &defs)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	if usesTime {
+		buf.WriteString("import \"time\"\n\n")
+	}
+
+	// the struct is a starting point to edit, so it is not marked as
+	// generated code
+	fmt.Fprintf(&buf, "// %s holds the variables of %s\n", typeName, filepath.Base(file))
+	for _, def := range defs {
+		fmt.Fprintf(&buf, "type %s struct {\n", def.name)
+		for _, field := range def.fields {
+			buf.WriteString(field + "\n")
+		}
+		buf.WriteString("}\n\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// buildStruct adds the struct holding the entries and the structs nested in
+// it to defs, and returns whether one of them has a duration field
+func buildStruct(name string, path string, entries []envEntry, defs *[]structDef) bool {
+	index := len(*defs)
+	*defs = append(*defs, structDef{name: name})
+
+	// keys sharing their first segment are grouped, unless that segment is
+	// also a key of its own
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		if len(entry.segments) == 1 {
+			counts[entry.segments[0]] = -len(entries)
+		} else if entry.segments[0] != "" {
+			counts[entry.segments[0]]++
+		}
+	}
+
+	var fields []string
+	usesTime := false
+	names := make(map[string]bool)
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		prefix := entry.segments[0]
+
+		if counts[prefix] < 2 || len(entry.segments) == 1 {
+			fieldName := uniqueName(fieldName(entry.segments), names)
+			field, isDuration := genField(fieldName, strings.Join(entry.segments, "_"), entry.value)
+			fields = append(fields, field)
+			usesTime = usesTime || isDuration
+			continue
+		}
+
+		// the group is contiguous since the keys are sorted
+		var group []envEntry
+		for ; i < len(entries) && len(entries[i].segments) > 1 && entries[i].segments[0] == prefix; i++ {
+			group = append(group, envEntry{segments: entries[i].segments[1:], value: entries[i].value})
+		}
+		i--
+
+		fieldName := uniqueName(fieldName([]string{prefix}), names)
+		typeName := path + fieldName + "Config"
+		fields = append(fields, fmt.Sprintf("%s %s `env:%q`", fieldName, typeName, prefix))
+		if buildStruct(typeName, path+fieldName, group, defs) {
+			usesTime = true
+		}
+	}
+
+	(*defs)[index].fields = fields
+	return usesTime
+}
+
+// genField returns the declaration of the field of a variable, with a type
+// inferred from its example value
+func genField(name string, key string, value string) (string, bool) {
+	fieldType, rule := inferType(value)
+
+	tags := []string{fmt.Sprintf("env:%q", key)}
+	if secretKeyPattern.MatchString(key) {
+		tags = append(tags, `secret:"true"`)
+	} else if value != "" {
+		tags = append(tags, fmt.Sprintf("default:%q", value))
+	}
+	if rule != "" {
+		tags = append(tags, fmt.Sprintf("validate:%q", rule))
+	}
+
+	tag := strings.Join(tags, " ")
+	if strconv.CanBackquote(tag) {
+		tag = "`" + tag + "`"
+	} else {
+		tag = strconv.Quote(tag)
+	}
+
+	return fmt.Sprintf("%s %s %s", name, fieldType, tag), fieldType == "time.Duration"
+}
+
+// inferType returns the type of a field holding the value, and the
+// validation rule of URLs, which are loaded as strings
+func inferType(value string) (string, string) {
+	switch {
+	case value == "":
+		return "string", ""
+	case value == "true" || value == "false":
+		return "bool", ""
+	case isInt(value):
+		return "int", ""
+	case isFloat(value):
+		return "float64", ""
+	case isDuration(value):
+		return "time.Duration", ""
+	case isURL(value):
+		return "string", urlRule
+	case strings.Contains(value, ","):
+		for _, item := range strings.Split(value, ",") {
+			if strings.Count(item, ":") != 1 {
+				return "[]string", ""
+			}
+		}
+		return "map[string]string", ""
+	default:
+		return "string", ""
+	}
+}
+
+func isInt(value string) bool {
+	// numbers with leading zeros, like zip codes, are kept as strings
+	if len(value) > 1 && value[0] == '0' {
+		return false
+	}
+
+	_, err := strconv.Atoi(value)
+	return err == nil
+}
+
+func isFloat(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil && strings.Contains(value, ".")
+}
+
+func isDuration(value string) bool {
+	_, err := time.ParseDuration(value)
+	return err == nil && strings.IndexFunc(value, unicode.IsLetter) >= 0
+}
+
+func isURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// fieldName converts the segments of a key, like MAX_CONNS, to an exported
+// field name, like MaxConns
+func fieldName(segments []string) string {
+	var name strings.Builder
+	for _, segment := range segments {
+		upper := strings.ToUpper(segment)
+		if initialisms[upper] {
+			name.WriteString(upper)
+			continue
+		}
+
+		for i, r := range strings.ToLower(segment) {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				continue
+			}
+			if i == 0 {
+				r = unicode.ToUpper(r)
+			}
+			name.WriteRune(r)
+		}
+	}
+
+	str := name.String()
+	if str == "" || !unicode.IsLetter(rune(str[0])) {
+		str = "Field" + str
+	}
+
+	return str
+}
+
+// uniqueName returns the name, numbered when it is already taken
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	taken[unique] = true
+
+	return unique
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/metinorak/goenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenStruct(t *testing.T) {
+	values := map[string]string{
+		"APP_NAME":         "billing",
+		"DEBUG":            "false",
+		"DB_HOST":          "localhost",
+		"DB_PORT":          "5432",
+		"DB_PASSWORD":      "changeme",
+		"DB_POOL_MAX_CONN": "10",
+		"DB_POOL_TIMEOUT":  "1m30s",
+		"API_URL":          "https://api.example.com/v1",
+		"API_RATE":         "2.5",
+		"LABELS":           "team:billing,tier:1",
+		"ORIGINS":          "a.example.com,b.example.com",
+		"ZIP":              "01234",
+		"CACHE":            "redis",
+		"CACHE_TTL":        "5m0s",
+	}
+
+	source, err := genStruct(values, ".env.example", "config", "Config")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.go"), source, 0o600))
+
+	structType, err := loadStructType(dir + ".Config")
+	require.NoError(t, err)
+
+	// loading nothing gives the examples back as defaults, under their
+	// original names
+	model := reflect.New(structType).Interface()
+	loader, err := goenv.NewLoader(goenv.WithReader(goenv.MapReader{}))
+	require.NoError(t, err)
+	require.NoError(t, loader.Load(model))
+
+	loaded, err := goenv.Marshal(model)
+	require.NoError(t, err)
+
+	expected := make(map[string]string)
+	for key, value := range values {
+		expected[key] = value
+	}
+	expected["DB_PASSWORD"] = ""
+	assert.Equal(t, expected, loaded)
+
+	fields, err := goenv.Describe(model)
+	require.NoError(t, err)
+
+	types := make(map[string]string)
+	for _, field := range fields {
+		types[field.Key] = field.Type.String()
+		if field.Key == "DB_PASSWORD" {
+			assert.True(t, field.Secret)
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"APP_NAME":         "string",
+		"DEBUG":            "bool",
+		"DB_HOST":          "string",
+		"DB_PORT":          "int",
+		"DB_PASSWORD":      "string",
+		"DB_POOL_MAX_CONN": "int",
+		"DB_POOL_TIMEOUT":  "time.Duration",
+		"API_URL":          "string",
+		"API_RATE":         "float64",
+		"LABELS":           "map[string]string",
+		"ORIGINS":          "[]string",
+		"ZIP":              "string",
+		"CACHE":            "string",
+		"CACHE_TTL":        "time.Duration",
+	}, types)
+}
@@ -0,0 +1,68 @@
+// Command goenv works with the environment files of services using goenv.
+//
+// Usage:
+//
+//	goenv check -type dir.Name [-f file]... [-json]
+//	goenv diff [-type dir.Name] old new
+//	goenv exec [-f file]... [-override | -no-override] [-expand=false] -- command [args...]
+//	goenv gen-loader -type Name [-dir dir] [-o file]
+//	goenv gen-struct [-f file] [-pkg name] [-type name] [-o file]
+//	goenv seal [-key-file file] file...
+//	goenv unseal [-key-file file] file...
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// command is a subcommand, returning the exit code of the process
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) int
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{name: "check", usage: "check dotenv files against a config struct", run: runCheck},
+		{name: "diff", usage: "compare the variables of dotenv files or the environment", run: runDiff},
+		{name: "exec", usage: "run a command with the variables of dotenv files", run: runExec},
+		{name: "gen-loader", usage: "generate a loader of a config struct without reflection", run: runGenLoader},
+		{name: "gen-struct", usage: "generate a config struct from a dotenv file", run: runGenStruct},
+		{name: "seal", usage: "encrypt the values of dotenv files in place", run: runSeal},
+		{name: "unseal", usage: "decrypt the values of dotenv files in place", run: runUnseal},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return 2
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "goenv: unknown command %s\n", args[0])
+	printUsage()
+	return 2
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "usage: goenv <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+}
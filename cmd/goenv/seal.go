@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/metinorak/goenv"
+)
+
+func runSeal(args []string) int {
+	return runSealCommand("seal", args, goenv.Seal)
+}
+
+func runUnseal(args []string) int {
+	return runSealCommand("unseal", args, goenv.Unseal)
+}
+
+func runSealCommand(name string, args []string, fn func(path string, key []byte) error) int {
+	fs := flag.NewFlagSet("goenv "+name, flag.ContinueOnError)
+	keyFile := fs.String("key-file", "", "file holding the key, instead of $GOENV_KEY or $GOENV_KEY_FILE")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: goenv %s [-key-file file] file...\n", name)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	var key []byte
+	var err error
+	if *keyFile != "" {
+		key, err = goenv.LoadKeyFile(*keyFile)
+	} else {
+		key, err = goenv.LoadKey()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goenv %s: %s\n", name, err)
+		return 1
+	}
+
+	for _, path := range fs.Args() {
+		if err := fn(path, key); err != nil {
+			fmt.Fprintf(os.Stderr, "goenv %s: %s\n", name, err)
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	durationType  = reflect.TypeOf(time.Duration(0))
+	interfaceType = reflect.TypeOf((*any)(nil)).Elem()
+)
+
+var basicTypes = map[types.BasicKind]reflect.Type{
+	types.Bool:    reflect.TypeOf(false),
+	types.String:  reflect.TypeOf(""),
+	types.Int:     reflect.TypeOf(0),
+	types.Int8:    reflect.TypeOf(int8(0)),
+	types.Int16:   reflect.TypeOf(int16(0)),
+	types.Int32:   reflect.TypeOf(int32(0)),
+	types.Int64:   reflect.TypeOf(int64(0)),
+	types.Uint:    reflect.TypeOf(uint(0)),
+	types.Uint8:   reflect.TypeOf(uint8(0)),
+	types.Uint16:  reflect.TypeOf(uint16(0)),
+	types.Uint32:  reflect.TypeOf(uint32(0)),
+	types.Uint64:  reflect.TypeOf(uint64(0)),
+	types.Float32: reflect.TypeOf(float32(0)),
+	types.Float64: reflect.TypeOf(float64(0)),
+}
+
+// loadStructType type-checks the package of a type given as dir.Name, like
+// ./internal/config.Config, from its source without building it, and
+// returns a struct type with the same fields and tags that goenv can load
+// into. Fields of types goenv does not load are kept as interfaces.
+func loadStructType(spec string) (reflect.Type, error) {
+	structType, _, err := checkStructType(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return reflectStruct(structType), nil
+}
+
+// checkStructType type-checks the package of a struct type given as
+// dir.Name, and returns the struct with the package
+func checkStructType(spec string) (*types.Struct, *types.Package, error) {
+	i := strings.LastIndex(spec, ".")
+	if i <= 0 || i == len(spec)-1 || strings.ContainsAny(spec[i+1:], `/\`) {
+		return nil, nil, fmt.Errorf("type must be given as dir.Name, like ./internal/config.Config: %s", spec)
+	}
+	dir, name := spec[:i], spec[i+1:]
+
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(pkg.GoFiles))
+	for _, file := range pkg.GoFiles {
+		parsed, err := parser.ParseFile(fset, filepath.Join(dir, file), nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, parsed)
+	}
+
+	// errors in other parts of the package, like imports that cannot be
+	// found, leave the types they affect invalid but do not stop the check
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {},
+	}
+	checked, _ := conf.Check(pkg.ImportPath, fset, files, nil)
+
+	obj, ok := checked.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, nil, fmt.Errorf("type %s not found in %s", name, dir)
+	}
+
+	structType, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil, nil, fmt.Errorf("type %s is not a struct", name)
+	}
+
+	return structType, checked, nil
+}
+
+func reflectStruct(structType *types.Struct) reflect.Type {
+	var fields []reflect.StructField
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		if !field.Exported() {
+			continue
+		}
+
+		fields = append(fields, reflect.StructField{
+			Name: field.Name(),
+			Type: reflectType(field.Type()),
+			Tag:  reflect.StructTag(structType.Tag(i)),
+		})
+	}
+
+	return reflect.StructOf(fields)
+}
+
+func reflectType(t types.Type) reflect.Type {
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+		if obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Duration" {
+			return durationType
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		if basic, ok := basicTypes[u.Kind()]; ok {
+			return basic
+		}
+	case *types.Slice:
+		return reflect.SliceOf(reflectType(u.Elem()))
+	case *types.Map:
+		key := reflectType(u.Key())
+		if key.Comparable() {
+			return reflect.MapOf(key, reflectType(u.Elem()))
+		}
+	case *types.Struct:
+		return reflectStruct(u)
+	}
+
+	return interfaceType
+}
@@ -0,0 +1,108 @@
+package goenv
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// credentialsDirectoryEnv is the variable systemd sets to the directory
+// holding the credentials passed with LoadCredential= and SetCredential=
+const credentialsDirectoryEnv = "CREDENTIALS_DIRECTORY"
+
+// ErrCredentialsDirectoryNotSet is returned when the service is not started
+// with any systemd credentials
+var ErrCredentialsDirectoryNotSet = errors.New("environment variable " + credentialsDirectoryEnv + " is not set, is the service started with LoadCredential=?")
+
+type ErrInsecureCredential struct {
+	Path string
+	Mode fs.FileMode
+}
+
+func (e *ErrInsecureCredential) Error() string {
+	return fmt.Sprintf("credential file %s is accessible by group or others (mode %s)", e.Path, e.Mode.Perm())
+}
+
+// CredentialsOptions configures a CredentialsReader
+type CredentialsOptions struct {
+	// KeyFunc maps a credential name to the key it is looked up with.
+	// By default names are upper cased and dashes and dots become
+	// underscores, so db-password is read as DB_PASSWORD.
+	KeyFunc func(name string) string
+
+	// AllowInsecure permits reading credential files that the group or
+	// others have any permission on
+	AllowInsecure bool
+}
+
+// CredentialsReader reads the systemd credentials found in
+// $CREDENTIALS_DIRECTORY. They are read once when the reader is created, as
+// systemd does not change them during the lifetime of the service. A
+// trailing newline is not part of the value.
+type CredentialsReader struct {
+	values MapReader
+}
+
+// NewCredentialsReader reads every credential in $CREDENTIALS_DIRECTORY
+func NewCredentialsReader(opts CredentialsOptions) (*CredentialsReader, error) {
+	dir, ok := os.LookupEnv(credentialsDirectoryEnv)
+	if !ok || dir == "" {
+		return nil, ErrCredentialsDirectoryNotSet
+	}
+
+	keyFunc := opts.KeyFunc
+	if keyFunc == nil {
+		keyFunc = credentialKey
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials directory: %w", err)
+	}
+
+	values := make(MapReader)
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		if !opts.AllowInsecure && info.Mode().Perm()&0o077 != 0 {
+			return nil, &ErrInsecureCredential{
+				Path: path,
+				Mode: info.Mode(),
+			}
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		values[keyFunc(entry.Name())] = trimTrailingNewline(string(data))
+	}
+
+	return &CredentialsReader{values: values}, nil
+}
+
+func (r *CredentialsReader) LookupEnv(key string) (string, bool) {
+	return r.values.LookupEnv(key)
+}
+
+func (r *CredentialsReader) Environ() []string {
+	return r.values.Environ()
+}
+
+// credentialKey is the default mapping from credential names to keys
+func credentialKey(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
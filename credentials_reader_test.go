@@ -0,0 +1,73 @@
+package goenv
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialsReader(t *testing.T) {
+	t.Run("TestCredentialsReader_WithDefaultKeys", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "db-password"), []byte("secret"), 0o400))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "api.token"), []byte("token\n"), 0o600))
+		t.Setenv(credentialsDirectoryEnv, dir)
+
+		reader, err := NewCredentialsReader(CredentialsOptions{})
+		require.NoError(t, err)
+
+		value, ok := reader.LookupEnv("DB_PASSWORD")
+		assert.True(t, ok)
+		assert.Equal(t, "secret", value)
+
+		assert.Equal(t, []string{"API_TOKEN=token", "DB_PASSWORD=secret"}, reader.Environ())
+	})
+
+	t.Run("TestCredentialsReader_WithKeyFunc", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "password"), []byte("secret"), 0o400))
+		t.Setenv(credentialsDirectoryEnv, dir)
+
+		reader, err := NewCredentialsReader(CredentialsOptions{
+			KeyFunc: func(name string) string {
+				return "DATABASE_" + strings.ToUpper(name)
+			},
+		})
+		require.NoError(t, err)
+
+		value, ok := reader.LookupEnv("DATABASE_PASSWORD")
+		assert.True(t, ok)
+		assert.Equal(t, "secret", value)
+	})
+
+	t.Run("TestCredentialsReader_WhenFileIsInsecure", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "db-password"), []byte("secret"), 0o400))
+		require.NoError(t, os.Chmod(filepath.Join(dir, "db-password"), 0o644))
+		t.Setenv(credentialsDirectoryEnv, dir)
+
+		_, err := NewCredentialsReader(CredentialsOptions{})
+
+		var insecureErr *ErrInsecureCredential
+		assert.True(t, errors.As(err, &insecureErr))
+
+		reader, err := NewCredentialsReader(CredentialsOptions{AllowInsecure: true})
+		require.NoError(t, err)
+
+		_, ok := reader.LookupEnv("DB_PASSWORD")
+		assert.True(t, ok)
+	})
+
+	t.Run("TestCredentialsReader_WhenDirectoryIsNotSet", func(t *testing.T) {
+		t.Setenv(credentialsDirectoryEnv, "")
+		os.Unsetenv(credentialsDirectoryEnv)
+
+		_, err := NewCredentialsReader(CredentialsOptions{})
+		assert.ErrorIs(t, err, ErrCredentialsDirectoryNotSet)
+	})
+}
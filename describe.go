@@ -0,0 +1,77 @@
+package goenv
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FieldInfo describes a field of a model and the variable it is loaded from
+type FieldInfo struct {
+	// Key is the name of the variable
+	Key string
+
+	// Path is the Go path of the field from the model, like Database.Host
+	Path string
+	Type reflect.Type
+
+	Default     string
+	Required    bool
+	Secret      bool
+	Description string
+
+	// ItemSeparator is set for slices and maps, and KeyValueSeparator for
+	// maps only
+	ItemSeparator     string
+	KeyValueSeparator string
+
+	Rules []ValidationRule
+}
+
+// Describe returns the fields of the model in the order Load reads them,
+// without reading any variable. The model may be a struct or a pointer to
+// one, including a nil pointer.
+func Describe(model any) ([]FieldInfo, error) {
+	modelType := reflect.TypeOf(model)
+	if modelType != nil && modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType == nil || modelType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("model must be a struct or a pointer to a struct")
+	}
+
+	var fields []FieldInfo
+	err := walkModel("", reflect.New(modelType).Elem(), func(mf modelField) error {
+		rules, err := parseValidationRules(mf.field.getValidationRules())
+		if err != nil {
+			return fmt.Errorf("field %s: %w", mf.key, err)
+		}
+
+		defaultValue, _ := mf.field.getDefaultValue()
+		info := FieldInfo{
+			Key:         mf.key,
+			Path:        mf.path,
+			Type:        mf.value.Type(),
+			Default:     defaultValue,
+			Required:    mf.field.isRequired(),
+			Secret:      mf.field.isSecret(),
+			Description: mf.field.getDescription(),
+			Rules:       rules,
+		}
+
+		switch mf.value.Kind() {
+		case reflect.Map:
+			info.KeyValueSeparator = keyValueSeparator
+			info.ItemSeparator = itemSeparator
+		case reflect.Slice:
+			info.ItemSeparator = itemSeparator
+		}
+
+		fields = append(fields, info)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,79 @@
+package goenv
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	type DBConfig struct {
+		Host     string `default:"localhost"`
+		Password string `secret:"true" required:"true"`
+	}
+
+	type ConfigModel struct {
+		LogLevel string            `desc:"Level of the logs" validate:"oneof=debug info warn"`
+		Timeout  time.Duration     `validate:"min=1s,max=1m"`
+		Labels   map[string]string `env:"labels"`
+		Database DBConfig          `env:"db"`
+	}
+
+	t.Run("TestDescribe_WithNestedStructs", func(t *testing.T) {
+		fields, err := Describe((*ConfigModel)(nil))
+		require.NoError(t, err)
+
+		assert.Equal(t, []FieldInfo{
+			{
+				Key:         "LOG_LEVEL",
+				Path:        "LogLevel",
+				Type:        reflect.TypeOf(""),
+				Description: "Level of the logs",
+				Rules:       []ValidationRule{{Name: "oneof", Arg: "debug info warn"}},
+			},
+			{
+				Key:   "TIMEOUT",
+				Path:  "Timeout",
+				Type:  durationType,
+				Rules: []ValidationRule{{Name: "min", Arg: "1s"}, {Name: "max", Arg: "1m"}},
+			},
+			{
+				Key:               "labels",
+				Path:              "Labels",
+				Type:              reflect.TypeOf(map[string]string{}),
+				ItemSeparator:     ",",
+				KeyValueSeparator: ":",
+			},
+			{
+				Key:     "db_HOST",
+				Path:    "Database.Host",
+				Type:    reflect.TypeOf(""),
+				Default: "localhost",
+			},
+			{
+				Key:      "db_PASSWORD",
+				Path:     "Database.Password",
+				Type:     reflect.TypeOf(""),
+				Required: true,
+				Secret:   true,
+			},
+		}, fields)
+	})
+
+	t.Run("TestDescribe_WhenRuleIsInvalid", func(t *testing.T) {
+		type ConfigModel struct {
+			Name string `validate:"regex=["`
+		}
+
+		_, err := Describe(ConfigModel{})
+		assert.Error(t, err)
+	})
+
+	t.Run("TestDescribe_WhenModelIsNotStruct", func(t *testing.T) {
+		_, err := Describe(nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,91 @@
+package goenv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// kubernetesDataDir is the symlink Kubernetes swaps atomically to point
+// ConfigMap and Secret volumes at a new timestamped directory
+const kubernetesDataDir = "..data"
+
+// DirReader reads variables from a directory where each regular file name is
+// a key and its contents the value, like Kubernetes ConfigMap and Secret
+// volume mounts or /run/secrets. Files are read on every lookup, so updates
+// to the mount are picked up without recreating the reader. A trailing
+// newline, as written by echo or kubectl --from-file, is not part of the
+// value.
+type DirReader struct {
+	dir string
+}
+
+// NewDirReader creates a DirReader for the given directory
+func NewDirReader(dir string) *DirReader {
+	return &DirReader{dir: dir}
+}
+
+func (r *DirReader) LookupEnv(key string) (string, bool) {
+	if !isDirEntryKey(key) {
+		return "", false
+	}
+
+	return readRegularFile(filepath.Join(r.dir, key))
+}
+
+// Environ returns every key in the directory. When the directory is a
+// Kubernetes mount, the ..data symlink is resolved once so that all values
+// come from the same version even if it is swapped while reading.
+func (r *DirReader) Environ() []string {
+	dir := r.dir
+	if resolved, err := filepath.EvalSymlinks(filepath.Join(r.dir, kubernetesDataDir)); err == nil {
+		dir = resolved
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	values := make(map[string]string)
+	for _, entry := range entries {
+		key := entry.Name()
+		if !isDirEntryKey(key) {
+			continue
+		}
+
+		if value, ok := readRegularFile(filepath.Join(dir, key)); ok {
+			values[key] = value
+		}
+	}
+
+	return environFromMap(values)
+}
+
+// isDirEntryKey reports whether the name can be a key, which excludes paths
+// and the ..data and ..<timestamp> entries Kubernetes manages
+func isDirEntryKey(name string) bool {
+	return name != "" && !strings.HasPrefix(name, "..") && !strings.ContainsAny(name, `/\`)
+}
+
+// readRegularFile reads the file after following symlinks without its
+// trailing newline, skipping directories and other special files
+func readRegularFile(path string) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+
+	return trimTrailingNewline(string(data)), true
+}
+
+// trimTrailingNewline removes one trailing \n or \r\n from the contents of
+// a file holding a single value
+func trimTrailingNewline(str string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(str, "\n"), "\r")
+}
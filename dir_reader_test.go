@@ -0,0 +1,128 @@
+package goenv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metinorak/goenv/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKubernetesMount lays out dir the way the kubelet does: the values live
+// in a timestamped directory, ..data points to it and every key is a symlink
+// through ..data
+func writeKubernetesMount(t *testing.T, dir string, version string, values map[string]string) {
+	t.Helper()
+
+	versionDir := filepath.Join(dir, "..2024_01_01_00_00_00."+version)
+	require.NoError(t, os.Mkdir(versionDir, 0o755))
+
+	for key, value := range values {
+		require.NoError(t, os.WriteFile(filepath.Join(versionDir, key), []byte(value), 0o644))
+	}
+
+	tmpLink := filepath.Join(dir, "..data_tmp")
+	require.NoError(t, os.Symlink(filepath.Base(versionDir), tmpLink))
+	require.NoError(t, os.Rename(tmpLink, filepath.Join(dir, kubernetesDataDir)))
+
+	for key := range values {
+		link := filepath.Join(dir, key)
+		if _, err := os.Lstat(link); err == nil {
+			continue
+		}
+		require.NoError(t, os.Symlink(filepath.Join(kubernetesDataDir, key), link))
+	}
+}
+
+func TestDirReader(t *testing.T) {
+	t.Run("TestDirReader_WithPlainFiles", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "DB_PASSWORD"), []byte("secret"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "PORT"), []byte("5432\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "DEBUG"), []byte("true\r\n"), 0o600))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0o755))
+
+		reader := NewDirReader(dir)
+
+		value, ok := reader.LookupEnv("DB_PASSWORD")
+		assert.True(t, ok)
+		assert.Equal(t, "secret", value)
+
+		// the trailing newline of files written with echo is trimmed
+		value, _ = reader.LookupEnv("PORT")
+		assert.Equal(t, "5432", value)
+		value, _ = reader.LookupEnv("DEBUG")
+		assert.Equal(t, "true", value)
+
+		_, ok = reader.LookupEnv("nested")
+		assert.False(t, ok)
+
+		_, ok = reader.LookupEnv("../DB_PASSWORD")
+		assert.False(t, ok)
+
+		assert.Equal(t, []string{"DB_PASSWORD=secret", "DEBUG=true", "PORT=5432"}, reader.Environ())
+	})
+
+	t.Run("TestDirReader_WithKubernetesMount", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKubernetesMount(t, dir, "1", map[string]string{"HOST": "localhost", "PORT": "5432"})
+
+		reader := NewDirReader(dir)
+
+		value, ok := reader.LookupEnv("HOST")
+		assert.True(t, ok)
+		assert.Equal(t, "localhost", value)
+
+		_, ok = reader.LookupEnv(kubernetesDataDir)
+		assert.False(t, ok)
+
+		assert.Equal(t, []string{"HOST=localhost", "PORT=5432"}, reader.Environ())
+	})
+
+	t.Run("TestDirReader_WhenMountIsSwapped", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKubernetesMount(t, dir, "1", map[string]string{"HOST": "localhost"})
+
+		reader := NewDirReader(dir)
+
+		value, _ := reader.LookupEnv("HOST")
+		assert.Equal(t, "localhost", value)
+
+		writeKubernetesMount(t, dir, "2", map[string]string{"HOST": "db.internal"})
+
+		value, _ = reader.LookupEnv("HOST")
+		assert.Equal(t, "db.internal", value)
+		assert.Equal(t, []string{"HOST=db.internal"}, reader.Environ())
+	})
+
+	t.Run("TestDirReader_LayeredUnderEnvironment", func(t *testing.T) {
+		type ConfigModel struct {
+			Host string
+			Port int
+		}
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "HOST"), []byte("localhost"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "PORT"), []byte("5432"), 0o644))
+
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv("HOST").Return("", false)
+		mockEnvReader.EXPECT().LookupEnv("PORT").Return("6543", true)
+
+		loader, err := NewLoader(WithReader(NewChainReader(mockEnvReader, NewDirReader(dir))))
+		require.NoError(t, err)
+
+		config := &ConfigModel{}
+
+		err = loader.Load(config)
+		assert.NoError(t, err)
+
+		assert.Equal(t, &ConfigModel{Host: "localhost", Port: 6543}, config)
+	})
+}
@@ -0,0 +1,27 @@
+package goenv
+
+import "github.com/metinorak/goenv/internal/dotenv"
+
+// NewDotenvReader reads a dotenv file of KEY=value lines. Lines may start
+// with export, values may be single quoted to be taken literally or double
+// quoted to span lines and use \n, \t, \" and \\ escapes, and # starts a
+// comment outside of quotes.
+func NewDotenvReader(path string) (MapReader, error) {
+	return readDocumentFile(path, parseDotenv)
+}
+
+func parseDotenv(data []byte) (MapReader, error) {
+	lines, err := dotenv.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(MapReader)
+	for _, line := range lines {
+		if line.Key != "" {
+			values[line.Key] = line.Value
+		}
+	}
+
+	return values, nil
+}
@@ -1,13 +1,125 @@
 package goenv
 
-import "os"
+import (
+	"os"
+	"sort"
+	"strings"
+)
 
 type EnvReader interface {
 	LookupEnv(key string) (string, bool)
 }
 
+// CheckedEnvReader is implemented by readers whose lookups can fail, so that
+// the Loader reports why a variable could not be read instead of treating it
+// as not set
+type CheckedEnvReader interface {
+	EnvReader
+	LookupEnvChecked(key string) (string, bool, error)
+}
+
+// lookupEnvChecked looks the key up, returning the error of readers
+// implementing CheckedEnvReader
+func lookupEnvChecked(reader EnvReader, key string) (string, bool, error) {
+	if checked, ok := reader.(CheckedEnvReader); ok {
+		return checked.LookupEnvChecked(key)
+	}
+
+	value, ok := reader.LookupEnv(key)
+	return value, ok, nil
+}
+
+// EnvLister is implemented by readers that can list every variable they
+// hold, in the KEY=value form of os.Environ
+type EnvLister interface {
+	Environ() []string
+}
+
 type DefaultEnvReader struct{}
 
 func (r *DefaultEnvReader) LookupEnv(key string) (string, bool) {
 	return os.LookupEnv(key)
 }
+
+func (r *DefaultEnvReader) Environ() []string {
+	return os.Environ()
+}
+
+// MapReader is an EnvReader backed by a map, used by readers that load all
+// of their variables upfront
+type MapReader map[string]string
+
+func (r MapReader) LookupEnv(key string) (string, bool) {
+	value, ok := r[key]
+	return value, ok
+}
+
+func (r MapReader) Environ() []string {
+	return environFromMap(r)
+}
+
+// ChainReader looks a key up in each of its readers in order and returns the
+// first value found, so earlier readers take precedence over later ones
+type ChainReader struct {
+	readers []EnvReader
+}
+
+// NewChainReader creates a ChainReader from readers ordered from the highest
+// to the lowest precedence
+func NewChainReader(readers ...EnvReader) *ChainReader {
+	return &ChainReader{readers: readers}
+}
+
+func (r *ChainReader) LookupEnv(key string) (string, bool) {
+	value, ok, _ := r.LookupEnvChecked(key)
+	return value, ok
+}
+
+// LookupEnvChecked stops at the first reader that fails to look the key up
+func (r *ChainReader) LookupEnvChecked(key string) (string, bool, error) {
+	for _, reader := range r.readers {
+		value, ok, err := lookupEnvChecked(reader, key)
+		if err != nil || ok {
+			return value, ok, err
+		}
+	}
+
+	return "", false, nil
+}
+
+// Environ merges the variables of the readers implementing EnvLister,
+// keeping the value of the reader with the highest precedence
+func (r *ChainReader) Environ() []string {
+	values := make(map[string]string)
+
+	for i := len(r.readers) - 1; i >= 0; i-- {
+		lister, ok := r.readers[i].(EnvLister)
+		if !ok {
+			continue
+		}
+
+		for _, kv := range lister.Environ() {
+			if key, value, ok := strings.Cut(kv, "="); ok {
+				values[key] = value
+			}
+		}
+	}
+
+	return environFromMap(values)
+}
+
+// environFromMap converts the map to the KEY=value form sorted by key
+func environFromMap(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		env = append(env, key+"="+values[key])
+	}
+
+	return env
+}
@@ -0,0 +1,101 @@
+package goenv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/metinorak/goenv/internal/dotenv"
+)
+
+// WriteExample writes a dotenv file listing every variable the model is
+// loaded from, set to its default value. Each variable is preceded by its
+// desc tag, its type and whether it is required or secret as comments, so
+// the output can be committed as .env.example.
+func WriteExample(w io.Writer, model any) error {
+	value, err := structValue(model)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	first := true
+	err = walkModel("", value, func(mf modelField) error {
+		if !first {
+			bw.WriteString("\n")
+		}
+		first = false
+
+		if desc := mf.field.getDescription(); desc != "" {
+			for _, line := range strings.Split(desc, "\n") {
+				fmt.Fprintf(bw, "# %s\n", line)
+			}
+		}
+
+		fmt.Fprintf(bw, "# type: %s\n", typeHint(mf.value.Type()))
+		if mf.field.isRequired() {
+			bw.WriteString("# required\n")
+		}
+		if mf.field.isSecret() {
+			bw.WriteString("# secret\n")
+		}
+
+		defaultValue, _ := mf.field.getDefaultValue()
+		fmt.Fprintf(bw, "%s=%s\n", mf.key, dotenv.FormatValue(defaultValue))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// WriteDotenv writes the values of the model as a dotenv file that
+// NewDotenvReader reads back, in the order of the fields. Fields tagged
+// secret:"true" are left out.
+func WriteDotenv(w io.Writer, model any) error {
+	value, err := structValue(model)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	err = walkModel("", value, func(mf modelField) error {
+		if mf.field.isSecret() {
+			return nil
+		}
+
+		encoded, err := marshalValue(mf.value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", mf.key, err)
+		}
+
+		fmt.Fprintf(bw, "%s=%s\n", mf.key, dotenv.FormatValue(encoded))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// typeHint describes how a value of the type is written in a variable
+func typeHint(t reflect.Type) string {
+	if t == durationType {
+		return "duration"
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		return fmt.Sprintf("list of %s, separated by %q", t.Elem().Kind(), itemSeparator)
+	case reflect.Map:
+		return fmt.Sprintf("map of %s to %s, like key%svalue%s...", t.Key().Kind(), t.Elem().Kind(), keyValueSeparator, itemSeparator)
+	default:
+		return t.Kind().String()
+	}
+}
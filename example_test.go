@@ -0,0 +1,86 @@
+package goenv
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type exampleConfig struct {
+	WebsiteURL string   `desc:"Public URL of the website" required:"true"`
+	UserRoles  []string `default:"admin,editor"`
+	Database   struct {
+		Host     string        `default:"localhost"`
+		Password string        `desc:"Password of the database user" secret:"true"`
+		Timeout  time.Duration `default:"5s"`
+	}
+	Greeting string `default:"hello world"`
+}
+
+func TestWriteExample(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteExample(&buf, exampleConfig{})
+	require.NoError(t, err)
+
+	assert.Equal(t, `# Public URL of the website
+# type: string
+# required
+WEBSITE_URL=
+
+# type: list of string, separated by ","
+USER_ROLES=admin,editor
+
+# type: string
+DATABASE_HOST=localhost
+
+# Password of the database user
+# type: string
+# secret
+DATABASE_PASSWORD=
+
+# type: duration
+DATABASE_TIMEOUT=5s
+
+# type: string
+GREETING="hello world"
+`, buf.String())
+
+	values, err := parseDotenv(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", values["GREETING"])
+}
+
+func TestWriteDotenv(t *testing.T) {
+	config := exampleConfig{
+		WebsiteURL: "https://example.com",
+		UserRoles:  []string{"admin"},
+		Greeting:   "hello \"world\"\n",
+	}
+	config.Database.Host = "db"
+	config.Database.Password = "secret"
+	config.Database.Timeout = time.Minute
+
+	var buf bytes.Buffer
+
+	err := WriteDotenv(&buf, &config)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "secret")
+
+	values, err := parseDotenv(buf.Bytes())
+	require.NoError(t, err)
+
+	loader, err := NewLoader(WithReader(values))
+	require.NoError(t, err)
+
+	loaded := &exampleConfig{}
+	err = loader.Load(loaded)
+	require.NoError(t, err)
+
+	expected := config
+	expected.Database.Password = ""
+	assert.Equal(t, &expected, loaded)
+}
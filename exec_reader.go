@@ -0,0 +1,195 @@
+package goenv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// execCache holds the output of the commands run by every ExecReader, so
+// that each command runs at most once during the lifetime of the process
+var execCache sync.Map
+
+type ErrExecCommand struct {
+	Command string
+	Stderr  string
+	Err     error
+}
+
+func (e *ErrExecCommand) Error() string {
+	if e.Stderr == "" {
+		return fmt.Sprintf("command %q failed: %s", e.Command, e.Err)
+	}
+
+	return fmt.Sprintf("command %q failed: %s: %s", e.Command, e.Err, e.Stderr)
+}
+
+func (e *ErrExecCommand) Unwrap() error {
+	return e.Err
+}
+
+// ExecConfig configures an ExecReader
+type ExecConfig struct {
+	// Commands maps keys to the command printing their value
+	Commands map[string]string
+
+	// Shell runs the commands with sh -c. Otherwise they are split into
+	// arguments at spaces outside of quotes and run directly.
+	Shell bool
+
+	// Timeout is how long a command may run, 10s by default
+	Timeout time.Duration
+}
+
+// ExecReader reads values from the output of commands, for secrets kept in
+// password managers like pass show db/password or op read op://vault/db/pw.
+// The output is trimmed of surrounding whitespace.
+type ExecReader struct {
+	config ExecConfig
+
+	mu       sync.Mutex
+	commands map[string]string
+}
+
+// NewExecReader creates an ExecReader running the configured commands
+func NewExecReader(config ExecConfig) *ExecReader {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	commands := make(map[string]string, len(config.Commands))
+	for key, command := range config.Commands {
+		commands[key] = command
+	}
+
+	return &ExecReader{config: config, commands: commands}
+}
+
+// BindTags registers the commands of the model's fields tagged with
+// cmd:"...", for the keys the fields are loaded from
+func (r *ExecReader) BindTags(model any) error {
+	if err := checkModel(model); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return walkModel("", reflect.ValueOf(model).Elem(), func(mf modelField) error {
+		if command, ok := mf.field.Tag.Lookup("cmd"); ok {
+			r.commands[mf.key] = command
+		}
+		return nil
+	})
+}
+
+func (r *ExecReader) LookupEnv(key string) (string, bool) {
+	value, ok, _ := r.LookupEnvChecked(key)
+	return value, ok
+}
+
+// LookupEnvChecked runs the command of the key, or returns its cached output
+func (r *ExecReader) LookupEnvChecked(key string) (string, bool, error) {
+	r.mu.Lock()
+	command, ok := r.commands[key]
+	r.mu.Unlock()
+
+	if !ok {
+		return "", false, nil
+	}
+
+	cacheKey := fmt.Sprintf("%t:%s", r.config.Shell, command)
+	if output, ok := execCache.Load(cacheKey); ok {
+		return output.(string), true, nil
+	}
+
+	output, err := r.run(command)
+	if err != nil {
+		return "", false, err
+	}
+
+	execCache.Store(cacheKey, output)
+	return output, true, nil
+}
+
+func (r *ExecReader) run(command string) (string, error) {
+	var args []string
+	if r.config.Shell {
+		args = []string{"sh", "-c", command}
+	} else {
+		var err error
+		args, err = splitCommand(command)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.Timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("timed out after %s", r.config.Timeout)
+		}
+
+		return "", &ErrExecCommand{
+			Command: command,
+			Stderr:  strings.TrimSpace(stderr.String()),
+			Err:     err,
+		}
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// splitCommand splits the command into arguments at spaces outside of single
+// and double quotes
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	for _, r := range command {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command %q", command)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+
+	return args, nil
+}
@@ -0,0 +1,126 @@
+package goenv
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metinorak/goenv/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecReader(t *testing.T) {
+	t.Run("TestExecReader_WithCommands", func(t *testing.T) {
+		reader := NewExecReader(ExecConfig{
+			Commands: map[string]string{
+				"DB_PASSWORD": `printf "  %s\n" 'p@ss word'`,
+			},
+		})
+
+		value, ok, err := reader.LookupEnvChecked("DB_PASSWORD")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "p@ss word", value)
+
+		_, ok, err = reader.LookupEnvChecked("OTHER")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("TestExecReader_WithTags", func(t *testing.T) {
+		type ConfigModel struct {
+			Host     string
+			Database struct {
+				Password string `cmd:"echo tagged-secret"`
+			}
+		}
+
+		reader := NewExecReader(ExecConfig{})
+		require.NoError(t, reader.BindTags(&ConfigModel{}))
+
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv(gomock.Any()).Return("", false).AnyTimes()
+
+		loader, err := NewLoader(WithReader(NewChainReader(mockEnvReader, reader)))
+		require.NoError(t, err)
+
+		config := &ConfigModel{}
+
+		err = loader.Load(config)
+		require.NoError(t, err)
+
+		assert.Equal(t, "tagged-secret", config.Database.Password)
+	})
+
+	t.Run("TestExecReader_WithCache", func(t *testing.T) {
+		counter := filepath.Join(t.TempDir(), "counter")
+
+		reader := NewExecReader(ExecConfig{
+			Commands: map[string]string{
+				"COUNT": "echo x >> " + counter + "; wc -l < " + counter,
+			},
+			Shell: true,
+		})
+
+		for i := 0; i < 2; i++ {
+			value, _, err := reader.LookupEnvChecked("COUNT")
+			require.NoError(t, err)
+
+			// the command only ran once
+			assert.Equal(t, "1", value)
+		}
+	})
+
+	t.Run("TestExecReader_WhenCommandFails", func(t *testing.T) {
+		type ConfigModel struct {
+			DBPassword string `env:"DB_PASSWORD" cmd:"echo 'vault is sealed' >&2; exit 3"`
+		}
+
+		reader := NewExecReader(ExecConfig{Shell: true})
+		require.NoError(t, reader.BindTags(&ConfigModel{}))
+
+		loader, err := NewLoader(WithReader(reader))
+		require.NoError(t, err)
+
+		err = loader.Load(&ConfigModel{})
+
+		var execErr *ErrExecCommand
+		require.True(t, errors.As(err, &execErr))
+		assert.Equal(t, "vault is sealed", execErr.Stderr)
+		assert.Contains(t, err.Error(), "DB_PASSWORD")
+	})
+
+	t.Run("TestExecReader_WhenCommandTimesOut", func(t *testing.T) {
+		reader := NewExecReader(ExecConfig{
+			Commands: map[string]string{"SLOW": "sleep 5"},
+			Timeout:  50 * time.Millisecond,
+		})
+
+		_, _, err := reader.LookupEnvChecked("SLOW")
+		assert.ErrorContains(t, err, "timed out")
+	})
+
+	t.Run("TestExecReader_WhenCommandIsNotFound", func(t *testing.T) {
+		reader := NewExecReader(ExecConfig{
+			Commands: map[string]string{"MISSING": filepath.Join(t.TempDir(), "missing")},
+		})
+
+		_, ok := reader.LookupEnv("MISSING")
+		assert.False(t, ok)
+	})
+}
+
+func TestSplitCommand(t *testing.T) {
+	args, err := splitCommand(`op read "op://vault/db/pass word" --no-newline ''`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op", "read", "op://vault/db/pass word", "--no-newline", ""}, args)
+
+	_, err = splitCommand(`pass show "db`)
+	assert.Error(t, err)
+}
@@ -1,9 +1,6 @@
 package goenv
 
-import (
-	"reflect"
-	"strings"
-)
+import "reflect"
 
 type structField reflect.StructField
 
@@ -15,14 +12,32 @@ func (sf structField) isRequired() bool {
 	return false
 }
 
+func (sf structField) shouldResolve() bool {
+	if tag, ok := sf.Tag.Lookup("resolve"); ok && tag == "true" {
+		return true
+	}
+
+	return false
+}
+
+func (sf structField) isSecret() bool {
+	if tag, ok := sf.Tag.Lookup("secret"); ok && tag == "true" {
+		return true
+	}
+
+	return false
+}
+
 func (sf structField) getDefaultValue() (string, bool) {
 	return sf.Tag.Lookup("default")
 }
 
-func (sf structField) toSnakeUpperCase(str string) string {
-	snake := matchFirstCap.ReplaceAllString(str, "${1}_${2}")
-	snake = matchAllCap.ReplaceAllString(snake, "${1}_${2}")
-	return strings.ToUpper(snake)
+func (sf structField) getDescription() string {
+	return sf.Tag.Get("desc")
+}
+
+func (sf structField) getValidationRules() string {
+	return sf.Tag.Get("validate")
 }
 
 func (sf structField) getEnvName() string {
@@ -32,7 +47,7 @@ func (sf structField) getEnvName() string {
 		key = tag
 	} else {
 		// otherwise, use the field name
-		key = sf.toSnakeUpperCase(sf.Name)
+		key = toSnakeUpperCase(sf.Name)
 	}
 
 	return key
@@ -0,0 +1,173 @@
+package goenv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// NewJSONReader reads a JSON document and flattens it into keys following
+// the naming rules of struct fields, so {"database": {"maxConns": 10}} is
+// read as DATABASE_MAX_CONNS=10
+func NewJSONReader(path string) (MapReader, error) {
+	return readDocumentFile(path, parseJSON)
+}
+
+// NewYAMLReader reads a YAML document and flattens it like NewJSONReader
+func NewYAMLReader(path string) (MapReader, error) {
+	return readDocumentFile(path, parseYAML)
+}
+
+// NewTOMLReader reads a TOML document and flattens it like NewJSONReader
+func NewTOMLReader(path string) (MapReader, error) {
+	return readDocumentFile(path, parseTOML)
+}
+
+func readDocumentFile(path string, parse func(data []byte) (MapReader, error)) (MapReader, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+func parseJSON(data []byte) (MapReader, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document map[string]any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return flattenDocument(document), nil
+}
+
+func parseYAML(data []byte) (MapReader, error) {
+	var document map[string]any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	return flattenDocument(document), nil
+}
+
+func parseTOML(data []byte) (MapReader, error) {
+	var document map[string]any
+	if err := toml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	return flattenDocument(document), nil
+}
+
+func flattenDocument(document map[string]any) MapReader {
+	values := make(MapReader)
+	flattenValue("", document, values)
+	return values
+}
+
+// flattenValue stores the value under key, or its children under keys made
+// of key and their names. Objects holding only scalars are also stored under
+// key in the k:v form map fields are loaded from, and arrays of scalars are
+// stored in the comma separated form slice fields are loaded from.
+func flattenValue(key string, value any, values MapReader) {
+	switch v := value.(type) {
+	case map[string]any:
+		pairs := make([]string, 0, len(v))
+		for name, child := range v {
+			flattenValue(joinKey(key, documentKey(name)), child, values)
+
+			if scalar, ok := formatScalar(child); ok {
+				pairs = append(pairs, name+":"+scalar)
+			}
+		}
+
+		if key != "" && len(pairs) == len(v) && len(pairs) > 0 {
+			sort.Strings(pairs)
+			values[key] = strings.Join(pairs, ",")
+		}
+
+	case map[any]any:
+		converted := make(map[string]any, len(v))
+		for name, child := range v {
+			converted[fmt.Sprint(name)] = child
+		}
+		flattenValue(key, converted, values)
+
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			scalar, ok := formatScalar(item)
+			if !ok {
+				return
+			}
+			items = append(items, scalar)
+		}
+		values[key] = strings.Join(items, ",")
+
+	default:
+		if scalar, ok := formatScalar(v); ok && key != "" {
+			values[key] = scalar
+		}
+	}
+}
+
+func formatScalar(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	default:
+		return "", false
+	}
+}
+
+// documentKey converts a name used in a document or file to the form used
+// for struct fields, treating dots and dashes as word separators
+func documentKey(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == '-'
+	})
+
+	for i, part := range parts {
+		parts[i] = toSnakeUpperCase(part)
+	}
+
+	return strings.Join(parts, keyDelimiter)
+}
+
+func joinKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + keyDelimiter + key
+}
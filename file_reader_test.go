@@ -0,0 +1,143 @@
+package goenv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metinorak/goenv/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	return path
+}
+
+func TestFileReaders(t *testing.T) {
+	type DBConfig struct {
+		Host     string
+		Port     int
+		MaxConns int
+	}
+
+	type ConfigModel struct {
+		WebsiteURL       string
+		Debug            bool
+		Proxies          []string
+		FormulaConstants map[string]float64
+		Database         DBConfig
+	}
+
+	expected := &ConfigModel{
+		WebsiteURL:       "https://example.com",
+		Debug:            true,
+		Proxies:          []string{"proxy1", "proxy2"},
+		FormulaConstants: map[string]float64{"pi": 3.14, "e": 2.71828},
+		Database: DBConfig{
+			Host:     "db.internal",
+			Port:     5432,
+			MaxConns: 20,
+		},
+	}
+
+	documents := []struct {
+		name      string
+		path      string
+		newReader func(path string) (MapReader, error)
+	}{
+		{
+			name: "JSON",
+			path: writeFile(t, "config.json", `{
+				"websiteUrl": "https://example.com",
+				"debug": true,
+				"proxies": ["proxy1", "proxy2"],
+				"formulaConstants": {"pi": 3.14, "e": 2.71828},
+				"database": {"host": "localhost", "port": 5432, "maxConns": 20}
+			}`),
+			newReader: NewJSONReader,
+		},
+		{
+			name: "YAML",
+			path: writeFile(t, "config.yaml", `
+website_url: https://example.com
+debug: true
+proxies:
+  - proxy1
+  - proxy2
+formula_constants:
+  pi: 3.14
+  e: 2.71828
+database:
+  host: localhost
+  port: 5432
+  max-conns: 20
+`),
+			newReader: NewYAMLReader,
+		},
+		{
+			name: "TOML",
+			path: writeFile(t, "config.toml", `
+websiteUrl = "https://example.com"
+debug = true
+proxies = ["proxy1", "proxy2"]
+
+[formulaConstants]
+pi = 3.14
+e = 2.71828
+
+[database]
+host = "localhost"
+port = 5432
+maxConns = 20
+`),
+			newReader: NewTOMLReader,
+		},
+	}
+
+	for _, document := range documents {
+		document := document
+
+		t.Run("TestFileReaders_With"+document.name, func(t *testing.T) {
+			fileReader, err := document.newReader(document.path)
+			require.NoError(t, err)
+
+			value, ok := fileReader.LookupEnv("DATABASE_MAX_CONNS")
+			assert.True(t, ok)
+			assert.Equal(t, "20", value)
+
+			// Create mock EnvReader
+			mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+			// The environment overrides the host and leaves the rest to the file
+			mockEnvReader.EXPECT().LookupEnv("DATABASE_HOST").Return("db.internal", true)
+			mockEnvReader.EXPECT().LookupEnv(gomock.Any()).Return("", false).AnyTimes()
+
+			loader, err := NewLoader(WithReader(NewChainReader(mockEnvReader, fileReader)))
+			require.NoError(t, err)
+
+			config := &ConfigModel{}
+
+			err = loader.Load(config)
+			assert.NoError(t, err)
+
+			assert.Equal(t, expected, config)
+		})
+	}
+
+	t.Run("TestFileReaders_WhenDocumentIsNotValid", func(t *testing.T) {
+		_, err := NewJSONReader(writeFile(t, "config.json", `{"database": `))
+		assert.Error(t, err)
+	})
+
+	t.Run("TestFileReaders_WhenFileDoesNotExist", func(t *testing.T) {
+		_, err := NewYAMLReader(filepath.Join(t.TempDir(), "config.yaml"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,108 @@
+package goenv
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// BindFlags registers a flag on the flag set for every field of the model,
+// named after the field's key in lower kebab case, so DATABASE_HOST becomes
+// -database-host. The value found in the environment, or else the default
+// tag, is used as the flag default and the desc tag as its usage. Flags of
+// bool fields can be set without a value, like -debug.
+//
+// Parsed flags are read with an ArgsReader, which is meant to be layered
+// above the environment with a ChainReader.
+func BindFlags(fs *flag.FlagSet, model any) error {
+	return newDefaultLoader().BindFlags(fs, model)
+}
+
+// BindFlags registers the flags of the model like BindFlags, taking their
+// defaults from the Loader's reader
+func (l *Loader) BindFlags(fs *flag.FlagSet, model any) error {
+	if err := checkModel(model); err != nil {
+		return err
+	}
+
+	return walkModel("", reflect.ValueOf(model).Elem(), func(mf modelField) error {
+		name := flagName(mf.key)
+		if fs.Lookup(name) != nil {
+			return fmt.Errorf("flag %s for %s is already defined", name, mf.key)
+		}
+
+		value, ok, err := lookupEnvChecked(l.reader, mf.key)
+		if err != nil {
+			return fmt.Errorf("failed to read environment variable %s: %w", mf.key, err)
+		}
+		if !ok {
+			value, _ = mf.field.getDefaultValue()
+		}
+
+		if mf.value.Kind() == reflect.Bool {
+			fs.Var(&boolFlag{value: value}, name, mf.field.getDescription())
+		} else {
+			fs.String(name, value, mf.field.getDescription())
+		}
+		return nil
+	})
+}
+
+// boolFlag is the value of the flag of a bool field, which the flag package
+// sets to true when it is given without a value
+type boolFlag struct {
+	value string
+}
+
+func (f *boolFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *boolFlag) Set(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return err
+	}
+
+	f.value = value
+	return nil
+}
+
+func (f *boolFlag) IsBoolFlag() bool {
+	return true
+}
+
+// ArgsReader reads the flags of a flag set that were set on the command line
+type ArgsReader struct {
+	fs *flag.FlagSet
+}
+
+// NewArgsReader creates an ArgsReader for a flag set prepared with BindFlags
+func NewArgsReader(fs *flag.FlagSet) *ArgsReader {
+	return &ArgsReader{fs: fs}
+}
+
+// LookupEnv returns the value of the flag bound to the key, only if the flag
+// was set, so that flag defaults do not shadow other readers
+func (r *ArgsReader) LookupEnv(key string) (string, bool) {
+	name := flagName(key)
+
+	var value string
+	var found bool
+	r.fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			value = f.Value.String()
+			found = true
+		}
+	})
+
+	return value, found
+}
+
+func flagName(key string) string {
+	return strings.ToLower(strings.ReplaceAll(key, keyDelimiter, "-"))
+}
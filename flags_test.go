@@ -0,0 +1,111 @@
+package goenv
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metinorak/goenv/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindFlags(t *testing.T) {
+	type DBConfig struct {
+		Host string `desc:"Database host"`
+		Port int    `default:"5432"`
+	}
+
+	type ConfigModel struct {
+		WebsiteURL string `env:"-"`
+		Debug      bool
+		Database   DBConfig
+	}
+
+	t.Run("TestBindFlags_WithDefaults", func(t *testing.T) {
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv("DEBUG").Return("", false)
+		mockEnvReader.EXPECT().LookupEnv("DATABASE_HOST").Return("localhost", true)
+		mockEnvReader.EXPECT().LookupEnv("DATABASE_PORT").Return("", false)
+
+		// Replace the default EnvReader with the mock
+		envReader = mockEnvReader
+
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+		err := BindFlags(fs, &ConfigModel{})
+		require.NoError(t, err)
+
+		assert.Nil(t, fs.Lookup("website-url"))
+
+		hostFlag := fs.Lookup("database-host")
+		require.NotNil(t, hostFlag)
+		assert.Equal(t, "localhost", hostFlag.DefValue)
+		assert.Equal(t, "Database host", hostFlag.Usage)
+
+		portFlag := fs.Lookup("database-port")
+		require.NotNil(t, portFlag)
+		assert.Equal(t, "5432", portFlag.DefValue)
+	})
+
+	t.Run("TestBindFlags_WithArgsReader", func(t *testing.T) {
+		reader := MapReader{"DATABASE_HOST": "localhost"}
+
+		loader, err := NewLoader(WithReader(reader))
+		require.NoError(t, err)
+
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+		err = loader.BindFlags(fs, &ConfigModel{})
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", fs.Lookup("database-host").DefValue)
+
+		err = fs.Parse([]string{"--database-port", "6543", "-debug"})
+		require.NoError(t, err)
+
+		loader, err = NewLoader(WithReader(NewChainReader(NewArgsReader(fs), reader)))
+		require.NoError(t, err)
+
+		config := &ConfigModel{}
+
+		err = loader.Load(config)
+		assert.NoError(t, err)
+
+		expected := &ConfigModel{
+			Debug: true,
+			Database: DBConfig{
+				Host: "localhost",
+				Port: 6543,
+			},
+		}
+
+		assert.Equal(t, expected, config)
+	})
+
+	t.Run("TestBindFlags_WhenBoolFlagIsNotBool", func(t *testing.T) {
+		loader, err := NewLoader(WithReader(MapReader{}))
+		require.NoError(t, err)
+
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+
+		err = loader.BindFlags(fs, &ConfigModel{})
+		require.NoError(t, err)
+
+		err = fs.Parse([]string{"-debug=yes"})
+		assert.Error(t, err)
+	})
+
+	t.Run("TestBindFlags_WhenFlagIsAlreadyDefined", func(t *testing.T) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.String("debug", "", "")
+
+		err := BindFlags(fs, &ConfigModel{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,48 @@
+package goenv
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// NewFSReader reads files from any fs.FS, such as an embed.FS holding
+// defaults shipped inside the binary. Files ending in .json, .yaml, .yml or
+// .toml are flattened like NewJSONReader does and every other file is read
+// as dotenv. Files later in paths override the keys of earlier ones.
+func NewFSReader(fsys fs.FS, paths ...string) (MapReader, error) {
+	values := make(MapReader)
+
+	for _, name := range paths {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		fileValues, err := parserFor(name)(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+
+		for key, value := range fileValues {
+			values[key] = value
+		}
+	}
+
+	return values, nil
+}
+
+// parserFor picks the parser of a file from its extension
+func parserFor(name string) func(data []byte) (MapReader, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json":
+		return parseJSON
+	case ".yaml", ".yml":
+		return parseYAML
+	case ".toml":
+		return parseTOML
+	default:
+		return parseDotenv
+	}
+}
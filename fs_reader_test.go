@@ -0,0 +1,91 @@
+package goenv
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metinorak/goenv/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFSReader(t *testing.T) {
+	t.Run("TestFSReader_WithDotenv", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"defaults.env": {Data: []byte(`
+# defaults shipped with the binary
+export WEBSITE_URL=https://example.com
+DATABASE_HOST = localhost # inline comment
+DATABASE_PASSWORD='p@ss # word'
+GREETING="hello\tworld"
+CERTIFICATE="-----BEGIN-----
+abc
+-----END-----"
+`)},
+		}
+
+		reader, err := NewFSReader(fsys, "defaults.env")
+		require.NoError(t, err)
+
+		assert.Equal(t, MapReader{
+			"WEBSITE_URL":       "https://example.com",
+			"DATABASE_HOST":     "localhost",
+			"DATABASE_PASSWORD": "p@ss # word",
+			"GREETING":          "hello\tworld",
+			"CERTIFICATE":       "-----BEGIN-----\nabc\n-----END-----",
+		}, reader)
+	})
+
+	t.Run("TestFSReader_WithLayeredFiles", func(t *testing.T) {
+		type ConfigModel struct {
+			WebsiteURL string
+			Database   struct {
+				Host string
+				Port int
+			}
+		}
+
+		fsys := fstest.MapFS{
+			"config/defaults.yaml": {Data: []byte("websiteUrl: https://example.com\ndatabase:\n  host: localhost\n  port: 5432\n")},
+			"config/defaults.env":  {Data: []byte("DATABASE_HOST=db.internal\n")},
+		}
+
+		fsReader, err := NewFSReader(fsys, "config/defaults.yaml", "config/defaults.env")
+		require.NoError(t, err)
+
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv("WEBSITE_URL").Return("", false)
+		mockEnvReader.EXPECT().LookupEnv("DATABASE_HOST").Return("", false)
+		mockEnvReader.EXPECT().LookupEnv("DATABASE_PORT").Return("6543", true)
+
+		loader, err := NewLoader(WithReader(NewChainReader(mockEnvReader, fsReader)))
+		require.NoError(t, err)
+
+		config := &ConfigModel{}
+
+		err = loader.Load(config)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "https://example.com", config.WebsiteURL)
+		assert.Equal(t, "db.internal", config.Database.Host)
+		assert.Equal(t, 6543, config.Database.Port)
+	})
+
+	t.Run("TestFSReader_WhenDotenvIsNotValid", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"defaults.env": {Data: []byte("CERTIFICATE=\"-----BEGIN-----\n")},
+		}
+
+		_, err := NewFSReader(fsys, "defaults.env")
+		assert.Error(t, err)
+	})
+
+	t.Run("TestFSReader_WhenFileDoesNotExist", func(t *testing.T) {
+		_, err := NewFSReader(fstest.MapFS{}, "defaults.env")
+		assert.Error(t, err)
+	})
+}
@@ -6,11 +6,30 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
 var matchAllCap = regexp.MustCompile("([a-z0-9])([A-Z])")
 
+// keyDelimiter separates the names of nested struct fields in keys
+const keyDelimiter = "_"
+
+// itemSeparator separates the items of slices and the pairs of maps, and
+// keyValueSeparator the key and value of a pair, like in a:1,b:2
+const (
+	itemSeparator     = ","
+	keyValueSeparator = ":"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func toSnakeUpperCase(str string) string {
+	snake := matchFirstCap.ReplaceAllString(str, "${1}_${2}")
+	snake = matchAllCap.ReplaceAllString(snake, "${1}_${2}")
+	return strings.ToUpper(snake)
+}
+
 type ErrParseEnvValue struct {
 	Key   string
 	Value string
@@ -20,15 +39,23 @@ func (e *ErrParseEnvValue) Error() string {
 	return fmt.Sprintf("failed to parse environment variable %s: %s", e.Key, e.Value)
 }
 
+type ErrRequiredEnvValue struct {
+	Key string
+}
+
+func (e *ErrRequiredEnvValue) Error() string {
+	return fmt.Sprintf("required field %s is not set", e.Key)
+}
+
 var envReader EnvReader = &DefaultEnvReader{}
 
 func loadFromEnvToMap(envValue string, fieldValue reflect.Value) error {
-	pairs := strings.Split(envValue, ",")
+	pairs := strings.Split(envValue, itemSeparator)
 
 	mapValue := reflect.MakeMap(fieldValue.Type())
 
 	for _, pair := range pairs {
-		kv := strings.Split(pair, ":")
+		kv := strings.Split(pair, keyValueSeparator)
 		if len(kv) != 2 {
 			return fmt.Errorf("invalid map value: %s", envValue)
 		}
@@ -65,8 +92,23 @@ func loadFromEnvToMap(envValue string, fieldValue reflect.Value) error {
 	return nil
 }
 
-func loadFromEnvToModel(keyPrefix string, model any) error {
-	value := reflect.ValueOf(model).Elem()
+// modelField is a field of a model that is loaded from a single variable
+type modelField struct {
+	key string
+
+	// path is the Go path of the field from the model, like Database.Host
+	path  string
+	field structField
+	value reflect.Value
+}
+
+// walkModel calls fn for every field of the struct value that is loaded from
+// a variable, descending into nested structs and prefixing their keys
+func walkModel(keyPrefix string, value reflect.Value, fn func(mf modelField) error) error {
+	return walkModelFields(keyPrefix, "", value, fn)
+}
+
+func walkModelFields(keyPrefix string, pathPrefix string, value reflect.Value, fn func(mf modelField) error) error {
 	valueType := value.Type()
 
 	for i := 0; i < valueType.NumField(); i++ {
@@ -84,88 +126,150 @@ func loadFromEnvToModel(keyPrefix string, model any) error {
 		if keyPrefix == "" || keyPrefix == "-" {
 			currentKey = key
 		} else {
-			currentKey = fmt.Sprintf("%s%s%s", keyPrefix, "_", key)
+			currentKey = fmt.Sprintf("%s%s%s", keyPrefix, keyDelimiter, key)
+		}
+
+		path := field.Name
+		if pathPrefix != "" {
+			path = pathPrefix + "." + field.Name
 		}
 
 		if kindOfValue == reflect.Struct {
-			err := loadFromEnvToModel(currentKey, fieldValue.Addr().Interface())
+			err := walkModelFields(currentKey, path, fieldValue, fn)
 			if err != nil {
 				return err
 			}
 			continue
 		}
 
-		envValue, envExists := envReader.LookupEnv(currentKey)
+		err := fn(modelField{
+			key:   currentKey,
+			path:  path,
+			field: field,
+			value: fieldValue,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *Loader) loadFromEnvToModel(keyPrefix string, model any) error {
+	return walkModel(keyPrefix, reflect.ValueOf(model).Elem(), l.loadFromEnvToField)
+}
+
+func (l *Loader) loadFromEnvToField(mf modelField) error {
+	field, fieldValue, currentKey := mf.field, mf.value, mf.key
+
+	envValue, envExists, err := lookupEnvChecked(l.reader, currentKey)
+	if err != nil {
+		return fmt.Errorf("failed to read environment variable %s: %w", currentKey, err)
+	}
 
-		if field.isRequired() && !envExists {
-			return fmt.Errorf("required field %s is not set", key)
+	if field.isRequired() && !envExists {
+		return &ErrRequiredEnvValue{
+			Key: currentKey,
 		}
+	}
 
-		if !envExists && kindOfValue != reflect.Struct {
-			if defaultValue, ok := field.getDefaultValue(); ok {
-				envValue = defaultValue
-			}
+	if !envExists {
+		if defaultValue, ok := field.getDefaultValue(); ok {
+			envValue = defaultValue
 		}
+	}
 
-		if envValue == "" {
-			continue
+	if envValue != "" && (l.resolveAll || field.shouldResolve()) {
+		resolvedValue, err := l.resolve(envValue)
+		if err != nil {
+			return &ErrResolveEnvValue{
+				Key: currentKey,
+				Err: err,
+			}
 		}
+		envValue = resolvedValue
+	}
 
-		switch kindOfValue {
-		case reflect.String:
-			fieldValue.SetString(envValue)
+	if envValue == "" {
+		return nil
+	}
 
-		case reflect.Int:
-			intValue, err := strconv.Atoi(envValue)
-			if err != nil {
-				return &ErrParseEnvValue{
-					Key:   currentKey,
-					Value: envValue,
-				}
-			}
-			fieldValue.SetInt(int64(intValue))
+	if err := setFieldValue(currentKey, envValue, fieldValue); err != nil {
+		return err
+	}
 
-		case reflect.Float64:
-			floatValue, err := strconv.ParseFloat(envValue, 64)
-			if err != nil {
-				return &ErrParseEnvValue{
-					Key:   currentKey,
-					Value: envValue,
-				}
-			}
-			fieldValue.SetFloat(floatValue)
+	return validateField(currentKey, field, fieldValue)
+}
 
-		case reflect.Bool:
-			boolValue, err := strconv.ParseBool(envValue)
-			if err != nil {
-				return &ErrParseEnvValue{
-					Key:   currentKey,
-					Value: envValue,
-				}
+// setFieldValue parses the value of the variable into the field
+func setFieldValue(currentKey string, envValue string, fieldValue reflect.Value) error {
+	if fieldValue.Type() == durationType {
+		durationValue, err := time.ParseDuration(envValue)
+		if err != nil {
+			return &ErrParseEnvValue{
+				Key:   currentKey,
+				Value: envValue,
 			}
-			fieldValue.SetBool(boolValue)
+		}
+		fieldValue.SetInt(int64(durationValue))
 
-		case reflect.Slice:
-			sliceValue := strings.Split(envValue, ",")
-			fieldValue.Set(reflect.ValueOf(sliceValue))
+		return nil
+	}
 
-		case reflect.Map:
-			err := loadFromEnvToMap(envValue, fieldValue)
-			if err != nil {
-				return &ErrParseEnvValue{
-					Key:   currentKey,
-					Value: envValue,
-				}
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(envValue)
+
+	case reflect.Int:
+		intValue, err := strconv.Atoi(envValue)
+		if err != nil {
+			return &ErrParseEnvValue{
+				Key:   currentKey,
+				Value: envValue,
+			}
+		}
+		fieldValue.SetInt(int64(intValue))
+
+	case reflect.Float64:
+		floatValue, err := strconv.ParseFloat(envValue, 64)
+		if err != nil {
+			return &ErrParseEnvValue{
+				Key:   currentKey,
+				Value: envValue,
+			}
+		}
+		fieldValue.SetFloat(floatValue)
+
+	case reflect.Bool:
+		boolValue, err := strconv.ParseBool(envValue)
+		if err != nil {
+			return &ErrParseEnvValue{
+				Key:   currentKey,
+				Value: envValue,
+			}
+		}
+		fieldValue.SetBool(boolValue)
+
+	case reflect.Slice:
+		sliceValue := strings.Split(envValue, itemSeparator)
+		fieldValue.Set(reflect.ValueOf(sliceValue))
+
+	case reflect.Map:
+		err := loadFromEnvToMap(envValue, fieldValue)
+		if err != nil {
+			return &ErrParseEnvValue{
+				Key:   currentKey,
+				Value: envValue,
 			}
 		}
 	}
 
 	return nil
-
 }
 
-func loadFromEnv(model any) error {
-	// check the model type
+// checkModel verifies that the model is a pointer to a struct
+func checkModel(model any) error {
 	if reflect.TypeOf(model).Kind() != reflect.Ptr {
 		return fmt.Errorf("model must be a pointer")
 	}
@@ -174,16 +278,97 @@ func loadFromEnv(model any) error {
 		return fmt.Errorf("model must be a pointer to a struct")
 	}
 
+	return nil
+}
+
+func (l *Loader) loadFromEnv(model any) error {
+	// check the model type
+	if err := checkModel(model); err != nil {
+		return err
+	}
+
 	// find all env keys and set to model
-	return loadFromEnvToModel("", model)
+	return l.loadFromEnvToModel("", model)
 }
 
-// Loads the environment variables into the provided model
-func Load(model any) error {
-	err := loadFromEnv(model)
+// Loader loads environment variables into models using its EnvReader
+type Loader struct {
+	reader     EnvReader
+	files      []string
+	resolvers  map[string]Resolver
+	resolveAll bool
+}
+
+// Option configures a Loader
+type Option func(*Loader) error
+
+// WithReader makes the Loader look up variables through the given reader
+func WithReader(reader EnvReader) Option {
+	return func(l *Loader) error {
+		if reader == nil {
+			return fmt.Errorf("reader must not be nil")
+		}
+
+		l.reader = reader
+		return nil
+	}
+}
+
+// NewLoader creates a Loader that reads the process environment unless
+// another reader is provided with WithReader
+func NewLoader(opts ...Option) (*Loader, error) {
+	l := newDefaultLoader()
+
+	for _, opt := range opts {
+		if err := opt(l); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// Load loads the variables from the Loader's reader into the provided model
+func (l *Loader) Load(model any) error {
+	err := l.loadFromEnv(model)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// Check loads the model like Load, but goes on past the fields that cannot
+// be loaded and returns the errors of all of them
+func (l *Loader) Check(model any) []error {
+	if err := checkModel(model); err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	_ = walkModel("", reflect.ValueOf(model).Elem(), func(mf modelField) error {
+		if err := l.loadFromEnvToField(mf); err != nil {
+			errs = append(errs, err)
+		}
+		return nil
+	})
+
+	return errs
+}
+
+// Loads the environment variables into the provided model
+func Load(model any) error {
+	return newDefaultLoader().Load(model)
+}
+
+// newDefaultLoader creates a Loader reading the process environment with the
+// built-in resolvers registered
+func newDefaultLoader() *Loader {
+	l := &Loader{
+		reader:    envReader,
+		resolvers: make(map[string]Resolver),
+	}
+	l.registerBuiltinResolvers()
+
+	return l
+}
@@ -2,6 +2,7 @@ package goenv
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/metinorak/goenv/mocks"
@@ -277,6 +278,57 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, expected, config)
 	})
 
+	t.Run("TestLoad_WithDurations", func(t *testing.T) {
+		type ConfigModel struct {
+			Timeout       time.Duration
+			RetryInterval time.Duration `default:"1m30s"`
+		}
+
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv("TIMEOUT").Return("250ms", true)
+		mockEnvReader.EXPECT().LookupEnv("RETRY_INTERVAL").Return("", false)
+
+		// Replace the default EnvReader with the mock
+		envReader = mockEnvReader
+
+		// Call the Load method
+		config := &ConfigModel{}
+
+		err := Load(config)
+		assert.NoError(t, err)
+
+		expected := &ConfigModel{
+			Timeout:       250 * time.Millisecond,
+			RetryInterval: 90 * time.Second,
+		}
+
+		assert.Equal(t, expected, config)
+	})
+
+	t.Run("TestLoad_WhenDurationIsNotValid", func(t *testing.T) {
+		type ConfigModel struct {
+			Timeout time.Duration
+		}
+
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv("TIMEOUT").Return("250", true)
+
+		// Replace the default EnvReader with the mock
+		envReader = mockEnvReader
+
+		// Call the Load method
+		config := &ConfigModel{}
+
+		err := Load(config)
+		assert.Error(t, err)
+	})
+
 	t.Run("TestLoad_WithRequiredFields", func(t *testing.T) {
 		type ConfigModel struct {
 			WebsiteURL string `required:"true"`
@@ -507,6 +559,32 @@ func TestLoad(t *testing.T) {
 	})
 }
 
+func TestLoader_Check(t *testing.T) {
+	type ConfigModel struct {
+		Host     string `required:"true"`
+		Port     int
+		LogLevel string `validate:"oneof=debug info"`
+		Timeout  time.Duration
+	}
+
+	loader, err := NewLoader(WithReader(MapReader{
+		"PORT":      "http",
+		"LOG_LEVEL": "trace",
+		"TIMEOUT":   "5s",
+	}))
+	assert.NoError(t, err)
+
+	config := &ConfigModel{}
+	errs := loader.Check(config)
+
+	if assert.Len(t, errs, 3) {
+		assert.IsType(t, &ErrRequiredEnvValue{}, errs[0])
+		assert.IsType(t, &ErrParseEnvValue{}, errs[1])
+		assert.IsType(t, &ErrValidation{}, errs[2])
+	}
+	assert.Equal(t, 5*time.Second, config.Timeout)
+}
+
 func BenchmarkLoad(b *testing.B) {
 	type DBConfig struct {
 		Name     string
@@ -0,0 +1,35 @@
+// Package goenvtest helps testing the loaders generated by goenv gen-loader
+package goenvtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/metinorak/goenv"
+)
+
+// AssertLoadersAgree loads a T from each reader with goenv and with the
+// generated loader, and fails the test when they load different values or
+// return different errors
+func AssertLoadersAgree[T any](t testing.TB, generated func(goenv.EnvReader) (T, error), readers ...goenv.EnvReader) {
+	t.Helper()
+
+	for i, reader := range readers {
+		loader, err := goenv.NewLoader(goenv.WithReader(reader))
+		if err != nil {
+			t.Fatalf("reader %d: %s", i, err)
+		}
+
+		var want T
+		wantErr := loader.Load(&want)
+
+		got, gotErr := generated(reader)
+
+		switch {
+		case (wantErr == nil) != (gotErr == nil) || wantErr != nil && wantErr.Error() != gotErr.Error():
+			t.Errorf("reader %d: goenv returned error %v, the generated loader %v", i, wantErr, gotErr)
+		case wantErr == nil && !reflect.DeepEqual(want, got):
+			t.Errorf("reader %d: goenv loaded %+v, the generated loader %+v", i, want, got)
+		}
+	}
+}
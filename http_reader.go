@@ -0,0 +1,163 @@
+package goenv
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// HTTPConfig configures an HTTPReader
+type HTTPConfig struct {
+	// URL of the JSON document
+	URL string
+
+	// BearerToken is sent in the Authorization header when it is set
+	BearerToken string
+
+	// Username and Password are sent with basic authentication when
+	// Username is set
+	Username string
+	Password string
+
+	// Header holds additional headers sent with every request
+	Header http.Header
+
+	// HTTPClient is used for the requests, http.DefaultClient by default
+	HTTPClient *http.Client
+}
+
+// HTTPReader reads a JSON document served over HTTP and flattens it like
+// NewJSONReader. The document is only transferred again when its ETag
+// changes.
+type HTTPReader struct {
+	config HTTPConfig
+
+	mu     sync.Mutex
+	values MapReader
+	etag   string
+}
+
+// NewHTTPReader reads the document at the configured URL
+func NewHTTPReader(ctx context.Context, config HTTPConfig) (*HTTPReader, error) {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+
+	r := &HTTPReader{config: config}
+	if err := r.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *HTTPReader) LookupEnv(key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.values.LookupEnv(key)
+}
+
+func (r *HTTPReader) Environ() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.values.Environ()
+}
+
+// Refresh reads the document again unless the server reports that it has
+// not been modified
+func (r *HTTPReader) Refresh(ctx context.Context) error {
+	_, err := r.fetch(ctx)
+	return err
+}
+
+// Poll refreshes the document at every interval until the context is done.
+// notify is called with nil after the values change and with the error when
+// refreshing fails, in which case the previous values are kept.
+func (r *HTTPReader) Poll(ctx context.Context, interval time.Duration, notify func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := r.fetch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil || changed {
+			notify(err)
+		}
+	}
+}
+
+// fetch reads the document and reports whether its values changed
+func (r *HTTPReader) fetch(ctx context.Context) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, r.config.URL, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for name, values := range r.config.Header {
+		for _, value := range values {
+			request.Header.Add(name, value)
+		}
+	}
+	request.Header.Set("Accept", "application/json")
+
+	switch {
+	case r.config.BearerToken != "":
+		request.Header.Set("Authorization", "Bearer "+r.config.BearerToken)
+	case r.config.Username != "":
+		request.SetBasicAuth(r.config.Username, r.config.Password)
+	}
+
+	r.mu.Lock()
+	if r.etag != "" {
+		request.Header.Set("If-None-Match", r.etag)
+	}
+	r.mu.Unlock()
+
+	response, err := r.config.HTTPClient.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", r.config.URL, err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to read %s: server responded with %s", r.config.URL, response.Status)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", r.config.URL, err)
+	}
+
+	values, err := parseJSON(data)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", r.config.URL, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changed := !reflect.DeepEqual(values, r.values)
+	r.values = values
+	r.etag = response.Header.Get("ETag")
+
+	return changed, nil
+}
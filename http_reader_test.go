@@ -0,0 +1,105 @@
+package goenv
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPReader(t *testing.T) {
+	var mu sync.Mutex
+	version := 1
+	documents := map[int]string{
+		1: `{"database": {"host": "localhost", "port": 5432}}`,
+		2: `{"database": {"host": "db.internal", "port": 5432}}`,
+	}
+
+	var transfers int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "payments", r.Header.Get("X-Team"))
+
+		mu.Lock()
+		etag := `"v` + string(rune('0'+version)) + `"`
+		document := documents[version]
+		mu.Unlock()
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		atomic.AddInt32(&transfers, 1)
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(document))
+	}))
+	defer server.Close()
+
+	reader, err := NewHTTPReader(context.Background(), HTTPConfig{
+		URL:         server.URL,
+		BearerToken: "token",
+		Header:      http.Header{"X-Team": {"payments"}},
+	})
+	require.NoError(t, err)
+
+	value, ok := reader.LookupEnv("DATABASE_HOST")
+	assert.True(t, ok)
+	assert.Equal(t, "localhost", value)
+
+	t.Run("TestHTTPReader_WhenNotModified", func(t *testing.T) {
+		err := reader.Refresh(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&transfers))
+	})
+
+	t.Run("TestHTTPReader_WithPolling", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		notifications := make(chan error, 1)
+		go reader.Poll(ctx, 10*time.Millisecond, func(err error) {
+			notifications <- err
+		})
+
+		mu.Lock()
+		version = 2
+		mu.Unlock()
+
+		select {
+		case err := <-notifications:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no notification after the document changed")
+		}
+
+		value, _ := reader.LookupEnv("DATABASE_HOST")
+		assert.Equal(t, "db.internal", value)
+	})
+
+	t.Run("TestHTTPReader_WhenServerFails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "user", user)
+			assert.Equal(t, "password", password)
+
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		_, err := NewHTTPReader(context.Background(), HTTPConfig{
+			URL:      server.URL,
+			Username: "user",
+			Password: "password",
+		})
+		assert.Error(t, err)
+	})
+}
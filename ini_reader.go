@@ -0,0 +1,186 @@
+package goenv
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NewINIReader reads an INI file where section names become key prefixes,
+// so host=x in the [database] section is read as DATABASE_HOST=x
+func NewINIReader(path string) (MapReader, error) {
+	return readDocumentFile(path, parseINI)
+}
+
+// NewPropertiesReader reads a Java .properties file where dotted keys are
+// normalised like nested struct fields, so database.host is read as
+// DATABASE_HOST
+func NewPropertiesReader(path string) (MapReader, error) {
+	return readDocumentFile(path, parseProperties)
+}
+
+func parseINI(data []byte) (MapReader, error) {
+	values := make(MapReader)
+	section := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("line %d: unterminated section %s", lineNumber, line)
+			}
+			section = documentKey(strings.TrimSpace(line[1 : len(line)-1]))
+			continue
+		}
+
+		separator := strings.IndexAny(line, "=:")
+		if separator < 0 {
+			return nil, fmt.Errorf("line %d: expected key=value, got %s", lineNumber, line)
+		}
+
+		key := documentKey(strings.TrimSpace(line[:separator]))
+		if key == "" {
+			return nil, fmt.Errorf("line %d: empty key", lineNumber)
+		}
+
+		values[joinKey(section, key)] = unquoteINIValue(strings.TrimSpace(line[separator+1:]))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func unquoteINIValue(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+
+	return value
+}
+
+func parseProperties(data []byte) (MapReader, error) {
+	values := make(MapReader)
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// join the continuation lines of a logical line, dropping the
+		// backslash and the leading whitespace of the next line
+		for hasLineContinuation(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		if hasLineContinuation(line) {
+			line = line[:len(line)-1]
+		}
+
+		rawKey, rawValue := splitPropertiesLine(line)
+
+		key, err := unescapeProperties(rawKey)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := unescapeProperties(rawValue)
+		if err != nil {
+			return nil, err
+		}
+
+		values[documentKey(key)] = value
+	}
+
+	return values, nil
+}
+
+// hasLineContinuation reports whether the line ends with an odd number of
+// backslashes
+func hasLineContinuation(line string) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+
+	return count%2 == 1
+}
+
+// splitPropertiesLine splits the line at the first unescaped =, : or
+// whitespace, along with the whitespace around it
+func splitPropertiesLine(line string) (string, string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.IndexByte("=: \t\f", line[i]) >= 0 {
+			end = i
+			break
+		}
+	}
+
+	key := line[:end]
+	rest := strings.TrimLeft(line[end:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	return key, rest
+}
+
+func unescapeProperties(str string) (string, error) {
+	if !strings.Contains(str, `\`) {
+		return str, nil
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(str); i++ {
+		if str[i] != '\\' || i+1 == len(str) {
+			builder.WriteByte(str[i])
+			continue
+		}
+
+		i++
+		switch str[i] {
+		case 't':
+			builder.WriteByte('\t')
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'u':
+			if i+5 > len(str) {
+				return "", fmt.Errorf("malformed unicode escape in %s", str)
+			}
+			code, err := strconv.ParseUint(str[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed unicode escape in %s", str)
+			}
+			builder.WriteRune(rune(code))
+			i += 4
+		default:
+			builder.WriteByte(str[i])
+		}
+	}
+
+	return builder.String(), nil
+}
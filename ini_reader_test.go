@@ -0,0 +1,81 @@
+package goenv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestINIReader(t *testing.T) {
+	t.Run("TestINIReader_WithSections", func(t *testing.T) {
+		reader, err := NewINIReader(writeFile(t, "config.ini", `
+; global settings
+website_url = https://example.com
+
+[database]
+host = localhost
+port: 5432
+password = "p@ss=word"
+
+[server.http]
+# nested sections become nested prefixes
+maxConns = 10
+`))
+		require.NoError(t, err)
+
+		assert.Equal(t, MapReader{
+			"WEBSITE_URL":           "https://example.com",
+			"DATABASE_HOST":         "localhost",
+			"DATABASE_PORT":         "5432",
+			"DATABASE_PASSWORD":     "p@ss=word",
+			"SERVER_HTTP_MAX_CONNS": "10",
+		}, reader)
+	})
+
+	t.Run("TestINIReader_WhenLineIsNotValid", func(t *testing.T) {
+		_, err := NewINIReader(writeFile(t, "config.ini", "[database]\nhost\n"))
+		assert.Error(t, err)
+	})
+}
+
+func TestPropertiesReader(t *testing.T) {
+	t.Run("TestPropertiesReader_WithDottedKeys", func(t *testing.T) {
+		reader, err := NewPropertiesReader(writeFile(t, "config.properties", `
+# comment
+! another comment
+database.host=localhost
+database.port : 5432
+database.maxConns 10
+website.url = https://example.com
+`))
+		require.NoError(t, err)
+
+		assert.Equal(t, MapReader{
+			"DATABASE_HOST":      "localhost",
+			"DATABASE_PORT":      "5432",
+			"DATABASE_MAX_CONNS": "10",
+			"WEBSITE_URL":        "https://example.com",
+		}, reader)
+	})
+
+	t.Run("TestPropertiesReader_WithContinuationsAndEscapes", func(t *testing.T) {
+		reader, err := NewPropertiesReader(writeFile(t, "config.properties", "proxies = proxy1,\\\n    proxy2,\\\n    proxy3\n"+
+			"greeting = caf\\u00e9\\tbar\n"+
+			"path\\ name = C:\\\\temp\n"+
+			"trailing = \\\\\n"))
+		require.NoError(t, err)
+
+		assert.Equal(t, MapReader{
+			"PROXIES":   "proxy1,proxy2,proxy3",
+			"GREETING":  "café\tbar",
+			"PATH NAME": `C:\temp`,
+			"TRAILING":  `\`,
+		}, reader)
+	})
+
+	t.Run("TestPropertiesReader_WhenUnicodeEscapeIsNotValid", func(t *testing.T) {
+		_, err := NewPropertiesReader(writeFile(t, "config.properties", "key = \\u00zz\n"))
+		assert.Error(t, err)
+	})
+}
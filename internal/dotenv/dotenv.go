@@ -0,0 +1,143 @@
+// Package dotenv parses dotenv files line by line, keeping what goenv and
+// its command need to rewrite files and to expand values
+package dotenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Line is a line of a dotenv file, or the lines of a quoted value spanning
+// several of them
+type Line struct {
+	// Text is the line as written
+	Text string
+
+	// Key is empty for blank lines and comments
+	Key    string
+	Value  string
+	Export bool
+
+	// Literal is true for single quoted values, in which references like
+	// $VAR are not meant to be expanded
+	Literal bool
+}
+
+// String returns the text of the line, rewritten from its key and value
+// when it is an assignment
+func (l Line) String() string {
+	if l.Key == "" {
+		return l.Text
+	}
+
+	prefix := ""
+	if l.Export {
+		prefix = "export "
+	}
+
+	// single quotes are kept, unless the value cannot be written in them
+	if l.Literal && !strings.ContainsAny(l.Value, "'\n") {
+		return prefix + l.Key + "='" + l.Value + "'"
+	}
+
+	return prefix + l.Key + "=" + FormatValue(l.Value)
+}
+
+// Parse splits the data of a dotenv file into lines
+func Parse(data []byte) ([]Line, error) {
+	var parsed []Line
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		text := lines[i]
+		line := strings.TrimSpace(text)
+
+		if line == "" || line[0] == '#' {
+			parsed = append(parsed, Line{Text: text})
+			continue
+		}
+
+		export := strings.HasPrefix(line, "export ")
+		line = strings.TrimPrefix(line, "export ")
+
+		key, rest, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=value, got %s", lineNumber, line)
+		}
+
+		rest = strings.TrimSpace(rest)
+
+		var value string
+		literal := false
+		switch {
+		case strings.HasPrefix(rest, `"`):
+			// double quoted values continue until the closing quote
+			for closingQuoteIndex(rest) < 0 && i+1 < len(lines) {
+				i++
+				rest += "\n" + lines[i]
+				text += "\n" + lines[i]
+			}
+
+			end := closingQuoteIndex(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted value for %s", lineNumber, key)
+			}
+			value = unescape(rest[1:end])
+
+		case strings.HasPrefix(rest, "'"):
+			end := strings.IndexByte(rest[1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted value for %s", lineNumber, key)
+			}
+			value = rest[1 : end+1]
+			literal = true
+
+		default:
+			value = rest
+			if index := strings.Index(value, " #"); index >= 0 {
+				value = strings.TrimSpace(value[:index])
+			}
+		}
+
+		parsed = append(parsed, Line{
+			Text:    text,
+			Key:     key,
+			Value:   value,
+			Export:  export,
+			Literal: literal,
+		})
+	}
+
+	return parsed, nil
+}
+
+// FormatValue quotes the value when it could not be read back as is
+func FormatValue(value string) string {
+	if !strings.ContainsAny(value, " \t\r\n#\"'\\") {
+		return value
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// closingQuoteIndex returns the index of the first unescaped double quote
+// after the opening one, or -1
+func closingQuoteIndex(str string) int {
+	for i := 1; i < len(str); i++ {
+		switch str[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func unescape(str string) string {
+	return strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\r`, "\r", `\"`, `"`, `\\`, `\`).Replace(str)
+}
@@ -0,0 +1,40 @@
+package dotenv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("TestParse_WithQuotedValues", func(t *testing.T) {
+		lines, err := Parse([]byte("# comment\nexport PASSWORD='pa$word'\nURL=\"http://$HOST\\n\"\nPORT=8080 # port\n"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []Line{
+			{Text: "# comment"},
+			{Text: "export PASSWORD='pa$word'", Key: "PASSWORD", Value: "pa$word", Export: true, Literal: true},
+			{Text: `URL="http://$HOST\n"`, Key: "URL", Value: "http://$HOST\n"},
+			{Text: "PORT=8080 # port", Key: "PORT", Value: "8080"},
+			{Text: ""},
+		}, lines)
+	})
+
+	t.Run("TestParse_WhenValueIsRewritten", func(t *testing.T) {
+		lines, err := Parse([]byte("PASSWORD='pa$word'\nNAME=a b"))
+		require.NoError(t, err)
+
+		// single quotes are kept, so the value stays literal
+		lines[0].Value = "pa$$word"
+		assert.Equal(t, "PASSWORD='pa$$word'", lines[0].String())
+
+		lines[1].Value = "it's"
+		assert.Equal(t, `NAME="it's"`, lines[1].String())
+	})
+
+	t.Run("TestParse_WhenQuoteIsNotClosed", func(t *testing.T) {
+		_, err := Parse([]byte("PASSWORD='secret\n"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,32 @@
+// Package loadertest holds a config struct using every feature goenv loads,
+// to check that generated loaders agree with goenv
+package loadertest
+
+import "time"
+
+//go:generate go run ../../cmd/goenv gen-loader -type Config
+
+type Level string
+
+type DBConfig struct {
+	Host     string `default:"localhost" validate:"regex=^[a-z.]+$"`
+	Port     int    `default:"5432" validate:"min=1,max=65535"`
+	Password string `required:"true" resolve:"true"`
+}
+
+type Config struct {
+	WebsiteURL string             `env:"websiteUrl"`
+	LogLevel   Level              `default:"info" validate:"oneof=debug info warn"`
+	Ratio      float64            `default:"0.5"`
+	Debug      bool               `default:"false"`
+	Timeout    time.Duration      `default:"5s"`
+	Proxies    []string           `default:"a,b"`
+	Weights    map[string]float64 `default:"pi:3.14"`
+	Labels     map[string]string
+	Ignored    string   `env:"-"`
+	Database   DBConfig `env:"db"`
+	Server     struct {
+		Host string
+		Port int
+	} `env:"-"`
+}
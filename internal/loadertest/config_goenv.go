@@ -0,0 +1,236 @@
+// Code generated by goenv gen-loader. DO NOT EDIT.
+
+package loadertest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/metinorak/goenv"
+)
+
+// Validation rules of the fields of Config, parsed once
+var (
+	configLogLevelRules     = goenv.MustCompileValidationRules("oneof=debug info warn")
+	configDatabaseHostRules = goenv.MustCompileValidationRules("regex=^[a-z.]+$")
+	configDatabasePortRules = goenv.MustCompileValidationRules("min=1,max=65535")
+)
+
+// LoadConfig loads a Config from the reader like goenv.Load, without reflection
+func LoadConfig(r goenv.EnvReader) (Config, error) {
+	lookup := func(key string) (string, bool, error) {
+		if checked, ok := r.(goenv.CheckedEnvReader); ok {
+			return checked.LookupEnvChecked(key)
+		}
+
+		value, ok := r.LookupEnv(key)
+		return value, ok, nil
+	}
+
+	var c Config
+	var v string
+	var err error
+	var ok bool
+
+	// WebsiteURL
+	v, _, err = lookup("websiteUrl")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "websiteUrl", err)
+	}
+	if v != "" {
+		c.WebsiteURL = v
+	}
+
+	// LogLevel
+	v, ok, err = lookup("LOG_LEVEL")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "LOG_LEVEL", err)
+	}
+	if !ok {
+		v = "info"
+	}
+	if v != "" {
+		c.LogLevel = Level(v)
+		if err := configLogLevelRules.Validate("LOG_LEVEL", c.LogLevel); err != nil {
+			return Config{}, err
+		}
+	}
+
+	// Ratio
+	v, ok, err = lookup("RATIO")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "RATIO", err)
+	}
+	if !ok {
+		v = "0.5"
+	}
+	if v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return Config{}, &goenv.ErrParseEnvValue{Key: "RATIO", Value: v}
+		}
+		c.Ratio = parsed
+	}
+
+	// Debug
+	v, ok, err = lookup("DEBUG")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "DEBUG", err)
+	}
+	if !ok {
+		v = "false"
+	}
+	if v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return Config{}, &goenv.ErrParseEnvValue{Key: "DEBUG", Value: v}
+		}
+		c.Debug = parsed
+	}
+
+	// Timeout
+	v, ok, err = lookup("TIMEOUT")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "TIMEOUT", err)
+	}
+	if !ok {
+		v = "5s"
+	}
+	if v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return Config{}, &goenv.ErrParseEnvValue{Key: "TIMEOUT", Value: v}
+		}
+		c.Timeout = parsed
+	}
+
+	// Proxies
+	v, ok, err = lookup("PROXIES")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "PROXIES", err)
+	}
+	if !ok {
+		v = "a,b"
+	}
+	if v != "" {
+		c.Proxies = strings.Split(v, ",")
+	}
+
+	// Weights
+	v, ok, err = lookup("WEIGHTS")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "WEIGHTS", err)
+	}
+	if !ok {
+		v = "pi:3.14"
+	}
+	if v != "" {
+		m := make(map[string]float64)
+		for _, pair := range strings.Split(v, ",") {
+			kv := strings.Split(pair, ":")
+			if len(kv) != 2 {
+				return Config{}, &goenv.ErrParseEnvValue{Key: "WEIGHTS", Value: v}
+			}
+			parsed, err := strconv.ParseFloat(kv[1], 64)
+			if err != nil {
+				return Config{}, &goenv.ErrParseEnvValue{Key: "WEIGHTS", Value: v}
+			}
+			m[kv[0]] = parsed
+		}
+		c.Weights = m
+	}
+
+	// Labels
+	v, _, err = lookup("LABELS")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "LABELS", err)
+	}
+	if v != "" {
+		m := make(map[string]string)
+		for _, pair := range strings.Split(v, ",") {
+			kv := strings.Split(pair, ":")
+			if len(kv) != 2 {
+				return Config{}, &goenv.ErrParseEnvValue{Key: "LABELS", Value: v}
+			}
+			m[kv[0]] = kv[1]
+		}
+		c.Labels = m
+	}
+
+	// Database.Host
+	v, ok, err = lookup("db_HOST")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "db_HOST", err)
+	}
+	if !ok {
+		v = "localhost"
+	}
+	if v != "" {
+		c.Database.Host = v
+		if err := configDatabaseHostRules.Validate("db_HOST", c.Database.Host); err != nil {
+			return Config{}, err
+		}
+	}
+
+	// Database.Port
+	v, ok, err = lookup("db_PORT")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "db_PORT", err)
+	}
+	if !ok {
+		v = "5432"
+	}
+	if v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return Config{}, &goenv.ErrParseEnvValue{Key: "db_PORT", Value: v}
+		}
+		c.Database.Port = parsed
+		if err := configDatabasePortRules.Validate("db_PORT", c.Database.Port); err != nil {
+			return Config{}, err
+		}
+	}
+
+	// Database.Password
+	v, ok, err = lookup("db_PASSWORD")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "db_PASSWORD", err)
+	}
+	if !ok {
+		return Config{}, &goenv.ErrRequiredEnvValue{Key: "db_PASSWORD"}
+	}
+	if v != "" {
+		if v, err = goenv.Resolve(r, v); err != nil {
+			return Config{}, &goenv.ErrResolveEnvValue{Key: "db_PASSWORD", Err: err}
+		}
+	}
+	if v != "" {
+		c.Database.Password = v
+	}
+
+	// Server.Host
+	v, _, err = lookup("HOST")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "HOST", err)
+	}
+	if v != "" {
+		c.Server.Host = v
+	}
+
+	// Server.Port
+	v, _, err = lookup("PORT")
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read environment variable %s: %w", "PORT", err)
+	}
+	if v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return Config{}, &goenv.ErrParseEnvValue{Key: "PORT", Value: v}
+		}
+		c.Server.Port = parsed
+	}
+
+	return c, nil
+}
@@ -0,0 +1,76 @@
+package loadertest
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/metinorak/goenv"
+	"github.com/metinorak/goenv/goenvtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	goenvtest.AssertLoadersAgree(t, LoadConfig,
+		goenv.MapReader{
+			"db_PASSWORD": "secret",
+		},
+		goenv.MapReader{
+			"websiteUrl":  "https://example.com",
+			"LOG_LEVEL":   "debug",
+			"RATIO":       "0.75",
+			"DEBUG":       "true",
+			"TIMEOUT":     "1m30s",
+			"PROXIES":     "x,y,z",
+			"WEIGHTS":     "e:2.71828,pi:3.14",
+			"LABELS":      "team:billing",
+			"db_HOST":     "db.internal",
+			"db_PORT":     "6432",
+			"db_PASSWORD": "base64://" + base64.StdEncoding.EncodeToString([]byte("secret")),
+			"HOST":        "0.0.0.0",
+			"PORT":        "8080",
+			"Ignored":     "ignored",
+		},
+		goenv.MapReader{
+			"LOG_LEVEL":   "",
+			"db_PASSWORD": "",
+		},
+		goenv.MapReader{},
+		goenv.MapReader{"db_PASSWORD": "env://MISSING"},
+		goenv.MapReader{"db_PASSWORD": "secret", "LOG_LEVEL": "trace"},
+		goenv.MapReader{"db_PASSWORD": "secret", "RATIO": "half"},
+		goenv.MapReader{"db_PASSWORD": "secret", "TIMEOUT": "5"},
+		goenv.MapReader{"db_PASSWORD": "secret", "WEIGHTS": "pi"},
+		goenv.MapReader{"db_PASSWORD": "secret", "LABELS": "a:b:c"},
+		goenv.MapReader{"db_PASSWORD": "secret", "db_PORT": "0"},
+		goenv.MapReader{"db_PASSWORD": "secret", "db_HOST": "DB"},
+	)
+}
+
+func BenchmarkLoadConfig(b *testing.B) {
+	reader := goenv.MapReader{
+		"websiteUrl":  "https://example.com",
+		"WEIGHTS":     "e:2.71828,pi:3.14",
+		"db_PASSWORD": "secret",
+	}
+
+	b.Run("Generated", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := LoadConfig(reader); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Reflection", func(b *testing.B) {
+		loader, err := goenv.NewLoader(goenv.WithReader(reader))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for i := 0; i < b.N; i++ {
+			var config Config
+			if err := loader.Load(&config); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package goenv
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema returns a JSON Schema (draft 2020-12) of the object holding the
+// variables the model is loaded from, keyed by their names. Properties are
+// typed after the fields, with their default, desc tag and validation rules:
+// oneof rules become enums, regex rules patterns and min and max rules the
+// matching bounds. Durations, slices and maps are described as strings, since
+// variables cannot hold JSON arrays or objects: maps with a pattern of their
+// key:value pairs, and slices with a pattern of their items when they have a
+// oneof rule. Regex rules and bounds of slices and maps, which apply to their
+// items, are left out.
+func JSONSchema(model any) ([]byte, error) {
+	fields, err := Describe(model)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]any, len(fields))
+	required := []string{}
+	for _, field := range fields {
+		property, err := jsonSchemaProperty(field)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Key, err)
+		}
+
+		properties[field.Key] = property
+		if field.Required {
+			required = append(required, field.Key)
+		}
+	}
+
+	schema := map[string]any{
+		"$schema":    jsonSchemaDraft,
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func jsonSchemaProperty(field FieldInfo) (map[string]any, error) {
+	property := jsonSchemaType(field.Type)
+
+	if field.Description != "" {
+		property["description"] = field.Description
+	}
+	if field.Secret {
+		property["writeOnly"] = true
+	}
+
+	if field.Default != "" {
+		defaultValue, err := jsonValue(field.Type, field.Default)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default value %s", field.Default)
+		}
+		property["default"] = defaultValue
+	}
+
+	isList := field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Map
+	for _, rule := range field.Rules {
+		switch rule.Name {
+		case "oneof":
+			if field.Type.Kind() == reflect.Slice {
+				options := strings.Fields(rule.Arg)
+				for i, option := range options {
+					options[i] = regexp.QuoteMeta(option)
+				}
+				property["pattern"] = jsonSchemaListPattern("(" + strings.Join(options, "|") + ")")
+				continue
+			}
+
+			var enum []any
+			for _, option := range strings.Fields(rule.Arg) {
+				value, err := jsonValue(field.Type, option)
+				if err != nil {
+					return nil, fmt.Errorf("invalid option %s in %s", option, rule)
+				}
+				enum = append(enum, value)
+			}
+			property["enum"] = enum
+
+		case regexRule:
+			if !isList {
+				property["pattern"] = rule.Arg
+			}
+
+		case "min", "max":
+			keyword := jsonSchemaBound(field.Type, rule.Name)
+			if keyword == "" {
+				continue
+			}
+
+			limit, err := strconv.ParseFloat(rule.Arg, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number in %s", rule)
+			}
+			property[keyword] = limit
+		}
+	}
+
+	return property, nil
+}
+
+func jsonSchemaType(t reflect.Type) map[string]any {
+	if t == durationType {
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Int:
+		return map[string]any{"type": "integer"}
+	case reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Map:
+		pair := fmt.Sprintf("[^%[1]s%[2]s]*%[2]s[^%[1]s%[2]s]*", regexp.QuoteMeta(itemSeparator), regexp.QuoteMeta(keyValueSeparator))
+		return map[string]any{"type": "string", "pattern": jsonSchemaListPattern(pair)}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+// jsonSchemaListPattern returns a pattern of values holding items that match
+// the item pattern, separated like Load splits them
+func jsonSchemaListPattern(item string) string {
+	return "^" + item + "(" + regexp.QuoteMeta(itemSeparator) + item + ")*$"
+}
+
+// jsonSchemaBound returns the keyword of a min or max rule for the type, or
+// an empty string for durations, slices and maps, which have no bound keyword
+func jsonSchemaBound(t reflect.Type, rule string) string {
+	if t == durationType {
+		return ""
+	}
+
+	var keywords [2]string
+	switch t.Kind() {
+	case reflect.Int, reflect.Float64:
+		keywords = [2]string{"minimum", "maximum"}
+	case reflect.String:
+		keywords = [2]string{"minLength", "maxLength"}
+	default:
+		return ""
+	}
+
+	if rule == "min" {
+		return keywords[0]
+	}
+	return keywords[1]
+}
+
+// jsonValue parses the value like Load does and returns it in a form that
+// encodes to JSON as the type of the schema
+func jsonValue(t reflect.Type, value string) (any, error) {
+	parsed := reflect.New(t).Elem()
+	if err := setFieldValue("", value, parsed); err != nil {
+		return nil, err
+	}
+
+	// durations, slices and maps are described as strings
+	if t == durationType || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		return value, nil
+	}
+
+	return parsed.Interface(), nil
+}
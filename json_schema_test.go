@@ -0,0 +1,63 @@
+package goenv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	type ConfigModel struct {
+		LogLevel string             `desc:"Level of the logs" default:"info" validate:"oneof=debug info warn"`
+		Port     int                `required:"true" validate:"min=1,max=65535"`
+		Timeout  time.Duration      `default:"5s" validate:"max=1m"`
+		Hosts    []string           `validate:"min=1,regex=^[a-z]+$"`
+		Weights  map[string]float64 `default:"a:0.5"`
+		Levels   []string           `default:"info" validate:"oneof=debug info"`
+		Password string             `secret:"true" required:"true"`
+	}
+
+	t.Run("TestJSONSchema_WithRules", func(t *testing.T) {
+		schema, err := JSONSchema(&ConfigModel{})
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"LOG_LEVEL": {
+					"type": "string",
+					"description": "Level of the logs",
+					"default": "info",
+					"enum": ["debug", "info", "warn"]
+				},
+				"PORT": {"type": "integer", "minimum": 1, "maximum": 65535},
+				"TIMEOUT": {"type": "string", "default": "5s"},
+				"HOSTS": {"type": "string"},
+				"WEIGHTS": {
+					"type": "string",
+					"pattern": "^[^,:]*:[^,:]*(,[^,:]*:[^,:]*)*$",
+					"default": "a:0.5"
+				},
+				"LEVELS": {
+					"type": "string",
+					"pattern": "^(debug|info)(,(debug|info))*$",
+					"default": "info"
+				},
+				"PASSWORD": {"type": "string", "writeOnly": true}
+			},
+			"required": ["PORT", "PASSWORD"]
+		}`, string(schema))
+	})
+
+	t.Run("TestJSONSchema_WhenDefaultIsNotValid", func(t *testing.T) {
+		type ConfigModel struct {
+			Port int `default:"http"`
+		}
+
+		_, err := JSONSchema(ConfigModel{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,150 @@
+package goenv
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KubernetesConfig configures the manifests written by WriteKubernetes
+type KubernetesConfig struct {
+	// Name is the name of the ConfigMap and of the Secret
+	Name      string
+	Namespace string
+
+	// EnvFrom makes the container take every key of the ConfigMap and the
+	// Secret with envFrom, instead of listing them under env
+	EnvFrom bool
+}
+
+type kubernetesMetadata struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+type kubernetesObject struct {
+	APIVersion string             `yaml:"apiVersion"`
+	Kind       string             `yaml:"kind"`
+	Metadata   kubernetesMetadata `yaml:"metadata"`
+	Type       string             `yaml:"type,omitempty"`
+	Data       map[string]string  `yaml:"data,omitempty"`
+	StringData map[string]string  `yaml:"stringData,omitempty"`
+}
+
+type kubernetesKeyRef struct {
+	Name     string `yaml:"name"`
+	Key      string `yaml:"key"`
+	Optional bool   `yaml:"optional,omitempty"`
+}
+
+type kubernetesEnvVar struct {
+	Name      string `yaml:"name"`
+	ValueFrom struct {
+		ConfigMapKeyRef *kubernetesKeyRef `yaml:"configMapKeyRef,omitempty"`
+		SecretKeyRef    *kubernetesKeyRef `yaml:"secretKeyRef,omitempty"`
+	} `yaml:"valueFrom"`
+}
+
+type kubernetesEnvFromSource struct {
+	ConfigMapRef *kubernetesMetadata `yaml:"configMapRef,omitempty"`
+	SecretRef    *kubernetesMetadata `yaml:"secretRef,omitempty"`
+}
+
+type kubernetesContainerEnv struct {
+	Env     []kubernetesEnvVar        `yaml:"env,omitempty"`
+	EnvFrom []kubernetesEnvFromSource `yaml:"envFrom,omitempty"`
+}
+
+// WriteKubernetes writes the Kubernetes manifests of the variables the model
+// is loaded from, as YAML documents: a ConfigMap holding the defaults of
+// plain fields, a Secret stub with an empty value for each field tagged
+// secret:"true", and the env or envFrom block of a container reading them,
+// to be copied into a Deployment. References to variables of fields that are
+// not required are optional. Required fields without a default are left out
+// of the ConfigMap and the Secret, so that pods fail to start until they are
+// set.
+func WriteKubernetes(w io.Writer, model any, cfg KubernetesConfig) error {
+	if cfg.Name == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+
+	fields, err := Describe(model)
+	if err != nil {
+		return err
+	}
+
+	metadata := kubernetesMetadata{Name: cfg.Name, Namespace: cfg.Namespace}
+	configMap := kubernetesObject{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Metadata:   metadata,
+		Data:       make(map[string]string),
+	}
+	secret := kubernetesObject{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   metadata,
+		Type:       "Opaque",
+		StringData: make(map[string]string),
+	}
+
+	var container kubernetesContainerEnv
+	hasPlainFields, hasSecretFields := false, false
+	for _, field := range fields {
+		ref := &kubernetesKeyRef{
+			Name:     cfg.Name,
+			Key:      field.Key,
+			Optional: !field.Required,
+		}
+
+		// an empty value would be loaded as set, skipping the required check
+		written := !field.Required || field.Default != ""
+
+		envVar := kubernetesEnvVar{Name: field.Key}
+		if field.Secret {
+			if written {
+				secret.StringData[field.Key] = ""
+			}
+			envVar.ValueFrom.SecretKeyRef = ref
+			hasSecretFields = true
+		} else {
+			if written {
+				configMap.Data[field.Key] = field.Default
+			}
+			envVar.ValueFrom.ConfigMapKeyRef = ref
+			hasPlainFields = true
+		}
+
+		if !cfg.EnvFrom {
+			container.Env = append(container.Env, envVar)
+		}
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if hasPlainFields {
+		if err := encoder.Encode(configMap); err != nil {
+			return err
+		}
+		if cfg.EnvFrom {
+			container.EnvFrom = append(container.EnvFrom, kubernetesEnvFromSource{ConfigMapRef: &kubernetesMetadata{Name: cfg.Name}})
+		}
+	}
+
+	if hasSecretFields {
+		if err := encoder.Encode(secret); err != nil {
+			return err
+		}
+		if cfg.EnvFrom {
+			container.EnvFrom = append(container.EnvFrom, kubernetesEnvFromSource{SecretRef: &kubernetesMetadata{Name: cfg.Name}})
+		}
+	}
+
+	if err := encoder.Encode(container); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
@@ -0,0 +1,154 @@
+package goenv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteKubernetes(t *testing.T) {
+	type DBConfig struct {
+		Host     string `default:"localhost"`
+		Port     int    `default:"5432"`
+		Password string `secret:"true" required:"true"`
+	}
+
+	type ConfigModel struct {
+		LogLevel string `default:"info"`
+		Database DBConfig
+	}
+
+	t.Run("TestWriteKubernetes_WithEnv", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := WriteKubernetes(&buf, ConfigModel{}, KubernetesConfig{Name: "api", Namespace: "prod"})
+		require.NoError(t, err)
+
+		assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: api
+  namespace: prod
+data:
+  DATABASE_HOST: localhost
+  DATABASE_PORT: "5432"
+  LOG_LEVEL: info
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: api
+  namespace: prod
+type: Opaque
+---
+env:
+  - name: LOG_LEVEL
+    valueFrom:
+      configMapKeyRef:
+        name: api
+        key: LOG_LEVEL
+        optional: true
+  - name: DATABASE_HOST
+    valueFrom:
+      configMapKeyRef:
+        name: api
+        key: DATABASE_HOST
+        optional: true
+  - name: DATABASE_PORT
+    valueFrom:
+      configMapKeyRef:
+        name: api
+        key: DATABASE_PORT
+        optional: true
+  - name: DATABASE_PASSWORD
+    valueFrom:
+      secretKeyRef:
+        name: api
+        key: DATABASE_PASSWORD
+`, buf.String())
+	})
+
+	t.Run("TestWriteKubernetes_WithEnvFrom", func(t *testing.T) {
+		type ConfigModel struct {
+			LogLevel string `default:"info"`
+		}
+
+		var buf bytes.Buffer
+
+		err := WriteKubernetes(&buf, ConfigModel{}, KubernetesConfig{Name: "api", EnvFrom: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: api
+data:
+  LOG_LEVEL: info
+---
+envFrom:
+  - configMapRef:
+      name: api
+`, buf.String())
+	})
+
+	t.Run("TestWriteKubernetes_WhenRequiredFieldHasNoDefault", func(t *testing.T) {
+		type ConfigModel struct {
+			LogLevel string `default:"info"`
+			Port     int    `required:"true"`
+			Password string `secret:"true" required:"true"`
+			APIKey   string `secret:"true"`
+		}
+
+		var buf bytes.Buffer
+
+		err := WriteKubernetes(&buf, ConfigModel{}, KubernetesConfig{Name: "api"})
+		require.NoError(t, err)
+
+		assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: api
+data:
+  LOG_LEVEL: info
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: api
+type: Opaque
+stringData:
+  API_KEY: ""
+---
+env:
+  - name: LOG_LEVEL
+    valueFrom:
+      configMapKeyRef:
+        name: api
+        key: LOG_LEVEL
+        optional: true
+  - name: PORT
+    valueFrom:
+      configMapKeyRef:
+        name: api
+        key: PORT
+  - name: PASSWORD
+    valueFrom:
+      secretKeyRef:
+        name: api
+        key: PASSWORD
+  - name: API_KEY
+    valueFrom:
+      secretKeyRef:
+        name: api
+        key: API_KEY
+        optional: true
+`, buf.String())
+	})
+
+	t.Run("TestWriteKubernetes_WhenNameIsEmpty", func(t *testing.T) {
+		err := WriteKubernetes(&bytes.Buffer{}, ConfigModel{}, KubernetesConfig{})
+		assert.Error(t, err)
+	})
+}
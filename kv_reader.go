@@ -0,0 +1,388 @@
+package goenv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConsulConfig configures a ConsulReader
+type ConsulConfig struct {
+	// Address of the Consul agent, $CONSUL_HTTP_ADDR or
+	// http://127.0.0.1:8500 by default
+	Address string
+
+	// Token is the ACL token, $CONSUL_HTTP_TOKEN by default
+	Token string
+
+	// Prefix is the key prefix loaded recursively, like service/app/
+	Prefix string
+
+	// WaitTime is how long a blocking query waits for a change before
+	// Consul answers, 5m by default
+	WaitTime time.Duration
+
+	// HTTPClient is used for the requests, http.DefaultClient by default
+	HTTPClient *http.Client
+}
+
+// ConsulReader reads the keys under a prefix of Consul's KV store, turning
+// their paths into keys, so service/app/database/host is read as
+// DATABASE_HOST for the service/app prefix
+type ConsulReader struct {
+	config ConsulConfig
+
+	mu     sync.Mutex
+	values MapReader
+	index  uint64
+}
+
+// NewConsulReader reads the keys under the configured prefix
+func NewConsulReader(ctx context.Context, config ConsulConfig) (*ConsulReader, error) {
+	if config.Address == "" {
+		config.Address = os.Getenv("CONSUL_HTTP_ADDR")
+	}
+	if config.Address == "" {
+		config.Address = "http://127.0.0.1:8500"
+	}
+	if !strings.Contains(config.Address, "://") {
+		config.Address = "http://" + config.Address
+	}
+	config.Address = strings.TrimSuffix(config.Address, "/")
+
+	if config.Token == "" {
+		config.Token = os.Getenv("CONSUL_HTTP_TOKEN")
+	}
+	if config.WaitTime == 0 {
+		config.WaitTime = 5 * time.Minute
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+
+	r := &ConsulReader{config: config}
+	if err := r.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *ConsulReader) LookupEnv(key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.values.LookupEnv(key)
+}
+
+func (r *ConsulReader) Environ() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.values.Environ()
+}
+
+// Refresh reads the keys under the prefix again
+func (r *ConsulReader) Refresh(ctx context.Context) error {
+	_, err := r.fetch(ctx, 0)
+	return err
+}
+
+// WaitForChange blocks until a key under the prefix changes, using Consul's
+// blocking queries, and then reads the keys again
+func (r *ConsulReader) WaitForChange(ctx context.Context) error {
+	for {
+		r.mu.Lock()
+		index := r.index
+		r.mu.Unlock()
+
+		changed, err := r.fetch(ctx, index)
+		if err != nil || changed {
+			return err
+		}
+	}
+}
+
+// fetch reads the keys under the prefix, blocking until the index of the
+// prefix is past index when it is not zero, and reports whether it changed
+func (r *ConsulReader) fetch(ctx context.Context, index uint64) (bool, error) {
+	query := url.Values{"recurse": {"true"}}
+	if index > 0 {
+		query.Set("index", strconv.FormatUint(index, 10))
+		query.Set("wait", r.config.WaitTime.String())
+	}
+
+	requestURL := fmt.Sprintf("%s/v1/kv/%s?%s", r.config.Address, strings.TrimPrefix(r.config.Prefix, "/"), query.Encode())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if r.config.Token != "" {
+		request.Header.Set("X-Consul-Token", r.config.Token)
+	}
+
+	response, err := r.config.HTTPClient.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("failed to read consul prefix %s: %w", r.config.Prefix, err)
+	}
+	defer response.Body.Close()
+
+	var entries []struct {
+		Key   string
+		Value []byte
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(response.Body).Decode(&entries); err != nil {
+			return false, fmt.Errorf("failed to read consul prefix %s: %w", r.config.Prefix, err)
+		}
+	case http.StatusNotFound:
+		// the prefix has no keys yet
+	default:
+		return false, fmt.Errorf("failed to read consul prefix %s: consul responded with %s", r.config.Prefix, response.Status)
+	}
+
+	newIndex, _ := strconv.ParseUint(response.Header.Get("X-Consul-Index"), 10, 64)
+
+	values := make(MapReader)
+	for _, entry := range entries {
+		// keys ending with a slash are folders
+		if strings.HasSuffix(entry.Key, "/") {
+			continue
+		}
+
+		if key := kvKey(r.config.Prefix, entry.Key); key != "" {
+			values[key] = string(entry.Value)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the index must be reset when it goes backwards, as Consul documents
+	changed := newIndex != r.index
+	if newIndex < r.index {
+		newIndex = 0
+	}
+
+	r.values = values
+	r.index = newIndex
+
+	return changed, nil
+}
+
+// EtcdConfig configures an EtcdReader
+type EtcdConfig struct {
+	// Endpoint of the etcd v3 JSON gateway, http://127.0.0.1:2379 by default
+	Endpoint string
+
+	// Prefix is the key prefix loaded recursively, like /service/app/
+	Prefix string
+
+	// HTTPClient is used for the requests, http.DefaultClient by default
+	HTTPClient *http.Client
+}
+
+// EtcdReader reads the keys under a prefix of etcd through its v3 JSON
+// gateway, turning their paths into keys like ConsulReader
+type EtcdReader struct {
+	config EtcdConfig
+
+	mu       sync.Mutex
+	values   MapReader
+	revision int64
+}
+
+// NewEtcdReader reads the keys under the configured prefix
+func NewEtcdReader(ctx context.Context, config EtcdConfig) (*EtcdReader, error) {
+	if config.Endpoint == "" {
+		config.Endpoint = "http://127.0.0.1:2379"
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+
+	r := &EtcdReader{config: config}
+	if err := r.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *EtcdReader) LookupEnv(key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.values.LookupEnv(key)
+}
+
+func (r *EtcdReader) Environ() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.values.Environ()
+}
+
+type etcdHeader struct {
+	Revision string `json:"revision"`
+}
+
+type etcdKeyValue struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Refresh reads the keys under the prefix again
+func (r *EtcdReader) Refresh(ctx context.Context) error {
+	body := map[string][]byte{
+		"key":       []byte(r.config.Prefix),
+		"range_end": etcdPrefixEnd(r.config.Prefix),
+	}
+
+	var response struct {
+		Header etcdHeader     `json:"header"`
+		Kvs    []etcdKeyValue `json:"kvs"`
+	}
+
+	httpResponse, err := r.post(ctx, "/v3/kv/range", body)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to read etcd prefix %s: %w", r.config.Prefix, err)
+	}
+
+	revision, err := strconv.ParseInt(response.Header.Revision, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to read etcd prefix %s: invalid revision %q", r.config.Prefix, response.Header.Revision)
+	}
+
+	values := make(MapReader)
+	for _, kv := range response.Kvs {
+		if key := kvKey(r.config.Prefix, string(kv.Key)); key != "" {
+			values[key] = string(kv.Value)
+		}
+	}
+
+	r.mu.Lock()
+	r.values = values
+	r.revision = revision
+	r.mu.Unlock()
+
+	return nil
+}
+
+// WaitForChange watches the prefix from the revision after the last read and
+// blocks until a key under it changes, then reads the keys again
+func (r *EtcdReader) WaitForChange(ctx context.Context) error {
+	r.mu.Lock()
+	revision := r.revision
+	r.mu.Unlock()
+
+	body := map[string]any{
+		"create_request": map[string]any{
+			"key":            []byte(r.config.Prefix),
+			"range_end":      etcdPrefixEnd(r.config.Prefix),
+			"start_revision": strconv.FormatInt(revision+1, 10),
+		},
+	}
+
+	response, err := r.post(ctx, "/v3/watch", body)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	decoder := json.NewDecoder(response.Body)
+	for {
+		var message struct {
+			Result struct {
+				Events []json.RawMessage `json:"events"`
+			} `json:"result"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+
+		if err := decoder.Decode(&message); err != nil {
+			return fmt.Errorf("failed to watch etcd prefix %s: %w", r.config.Prefix, err)
+		}
+
+		if message.Error != nil {
+			return fmt.Errorf("failed to watch etcd prefix %s: %s", r.config.Prefix, message.Error.Message)
+		}
+
+		if len(message.Result.Events) > 0 {
+			return r.Refresh(ctx)
+		}
+	}
+}
+
+func (r *EtcdReader) post(ctx context.Context, path string, body any) (*http.Response, error) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, r.config.Endpoint+path, bytes.NewReader(encoded))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := r.config.HTTPClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read etcd prefix %s: %w", r.config.Prefix, err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("failed to read etcd prefix %s: etcd responded with %s", r.config.Prefix, response.Status)
+	}
+
+	return response, nil
+}
+
+// etcdPrefixEnd returns the range end matching every key with the prefix
+func etcdPrefixEnd(prefix string) []byte {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+
+	// every key is matched when the prefix is empty or all 0xff
+	return []byte{0}
+}
+
+// kvKey turns the path of a key below the prefix into a key, naming each
+// segment like a struct field and joining them with the key delimiter
+func kvKey(prefix string, path string) string {
+	path = strings.TrimPrefix(path, prefix)
+
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, documentKey(segment))
+		}
+	}
+
+	return strings.Join(segments, keyDelimiter)
+}
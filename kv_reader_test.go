@@ -0,0 +1,118 @@
+package goenv
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsulReader(t *testing.T) {
+	// the stand-in answers blocking queries with the next version of the keys
+	versions := []string{
+		`[{"Key":"service/app/","Value":null},{"Key":"service/app/database/host","Value":"bG9jYWxob3N0"},{"Key":"service/app/database/maxConns","Value":"MTA="}]`,
+		`[{"Key":"service/app/database/host","Value":"ZGIuaW50ZXJuYWw="},{"Key":"service/app/database/maxConns","Value":"MTA="}]`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/kv/service/app/", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("recurse"))
+		assert.Equal(t, "acl-token", r.Header.Get("X-Consul-Token"))
+
+		version := 0
+		if r.URL.Query().Get("index") == "10" {
+			assert.NotEmpty(t, r.URL.Query().Get("wait"))
+			version = 1
+		}
+
+		w.Header().Set("X-Consul-Index", []string{"10", "11"}[version])
+		_, _ = w.Write([]byte(versions[version]))
+	}))
+	defer server.Close()
+
+	reader, err := NewConsulReader(context.Background(), ConsulConfig{
+		Address: server.URL,
+		Token:   "acl-token",
+		Prefix:  "service/app/",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"DATABASE_HOST=localhost", "DATABASE_MAX_CONNS=10"}, reader.Environ())
+
+	err = reader.WaitForChange(context.Background())
+	require.NoError(t, err)
+
+	value, ok := reader.LookupEnv("DATABASE_HOST")
+	assert.True(t, ok)
+	assert.Equal(t, "db.internal", value)
+}
+
+func TestEtcdReader(t *testing.T) {
+	encode := func(str string) string {
+		return base64.StdEncoding.EncodeToString([]byte(str))
+	}
+
+	var mu sync.Mutex
+	host := "localhost"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/kv/range", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, encode("/service/app/"), body["key"])
+		assert.Equal(t, encode("/service/app0"), body["range_end"])
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"header": map[string]string{"revision": "7"},
+			"kvs": []map[string]string{
+				{"key": encode("/service/app/database/host"), "value": encode(host)},
+				{"key": encode("/service/app/database/port"), "value": encode("2379")},
+			},
+		})
+	})
+	mux.HandleFunc("/v3/watch", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			CreateRequest struct {
+				StartRevision string `json:"start_revision"`
+			} `json:"create_request"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "8", body.CreateRequest.StartRevision)
+
+		_, _ = w.Write([]byte(`{"result":{"header":{"revision":"7"},"created":true}}` + "\n"))
+		w.(http.Flusher).Flush()
+
+		mu.Lock()
+		host = "db.internal"
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"result":{"header":{"revision":"8"},"events":[{"kv":{"key":"` + encode("/service/app/database/host") + `"}}]}}` + "\n"))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	reader, err := NewEtcdReader(context.Background(), EtcdConfig{
+		Endpoint: server.URL,
+		Prefix:   "/service/app/",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"DATABASE_HOST=localhost", "DATABASE_PORT=2379"}, reader.Environ())
+
+	err = reader.WaitForChange(context.Background())
+	require.NoError(t, err)
+
+	value, ok := reader.LookupEnv("DATABASE_HOST")
+	assert.True(t, ok)
+	assert.Equal(t, "db.internal", value)
+}
@@ -0,0 +1,91 @@
+package goenv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown writes a reference of the variables the model is loaded from
+// as Markdown tables of their key, type, default, whether they are required,
+// desc tag and validation rules. Fields of nested structs are grouped under
+// a heading with their Go path, like Database or Database.Primary, after the
+// fields of the model itself.
+func WriteMarkdown(w io.Writer, model any) error {
+	fields, err := Describe(model)
+	if err != nil {
+		return err
+	}
+
+	// the fields of the model come first, then the nested structs in the
+	// order of their first field
+	groups := map[string][]FieldInfo{}
+	order := []string{""}
+	for _, field := range fields {
+		group := ""
+		if i := strings.LastIndex(field.Path, "."); i >= 0 {
+			group = field.Path[:i]
+		}
+
+		if _, ok := groups[group]; !ok && group != "" {
+			order = append(order, group)
+		}
+		groups[group] = append(groups[group], field)
+	}
+
+	bw := bufio.NewWriter(w)
+	first := true
+	for _, group := range order {
+		if len(groups[group]) == 0 {
+			continue
+		}
+
+		if !first {
+			bw.WriteString("\n")
+		}
+		first = false
+
+		if group != "" {
+			fmt.Fprintf(bw, "## %s\n\n", group)
+		}
+
+		bw.WriteString("| Key | Type | Default | Required | Description | Validation |\n")
+		bw.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, field := range groups[group] {
+			rules := make([]string, len(field.Rules))
+			for i, rule := range field.Rules {
+				rules[i] = markdownCode(rule.String())
+			}
+
+			required := ""
+			if field.Required {
+				required = "yes"
+			}
+
+			fmt.Fprintf(bw, "| %s | %s | %s | %s | %s | %s |\n",
+				markdownCode(field.Key),
+				markdownCode(field.Type.String()),
+				markdownCode(field.Default),
+				required,
+				markdownCell(field.Description),
+				strings.Join(rules, "<br>"),
+			)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// markdownCell escapes the text so that it stays in its table cell
+func markdownCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>").Replace(text)
+}
+
+func markdownCode(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	return "`" + markdownCell(text) + "`"
+}
@@ -0,0 +1,50 @@
+package goenv
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	type PoolConfig struct {
+		MaxConns int `default:"10" validate:"min=1,max=100"`
+	}
+
+	type DBConfig struct {
+		Host string `desc:"Host of the database" required:"true"`
+		Pool PoolConfig
+	}
+
+	type ConfigModel struct {
+		Database DBConfig
+		LogLevel string        `desc:"Level of the logs | debug or info" default:"info" validate:"oneof=debug info"`
+		Timeout  time.Duration `default:"5s"`
+	}
+
+	var buf bytes.Buffer
+
+	err := WriteMarkdown(&buf, &ConfigModel{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "| Key | Type | Default | Required | Description | Validation |\n"+
+		"| --- | --- | --- | --- | --- | --- |\n"+
+		"| `LOG_LEVEL` | `string` | `info` |  | Level of the logs \\| debug or info | `oneof=debug info` |\n"+
+		"| `TIMEOUT` | `time.Duration` | `5s` |  |  |  |\n"+
+		"\n"+
+		"## Database\n"+
+		"\n"+
+		"| Key | Type | Default | Required | Description | Validation |\n"+
+		"| --- | --- | --- | --- | --- | --- |\n"+
+		"| `DATABASE_HOST` | `string` |  | yes | Host of the database |  |\n"+
+		"\n"+
+		"## Database.Pool\n"+
+		"\n"+
+		"| Key | Type | Default | Required | Description | Validation |\n"+
+		"| --- | --- | --- | --- | --- | --- |\n"+
+		"| `DATABASE_POOL_MAX_CONNS` | `int` | `10` |  |  | `min=1`<br>`max=100` |\n",
+		buf.String())
+}
@@ -0,0 +1,128 @@
+package goenv
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Marshal encodes the model into the variables Load reads it from, using the
+// same keys, so loading the result gives back an equal model. Slices are
+// joined with commas, maps are written as k:v pairs and durations in the
+// form time.ParseDuration reads. Values that Load could not read back, like
+// slice items holding commas, are reported as errors. Empty slices and maps
+// are read back as nil.
+func Marshal(model any) (map[string]string, error) {
+	value, err := structValue(model)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	err = walkModel("", value, func(mf modelField) error {
+		if _, ok := values[mf.key]; ok {
+			return fmt.Errorf("more than one field is loaded from %s", mf.key)
+		}
+
+		encoded, err := marshalValue(mf.value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", mf.key, err)
+		}
+
+		values[mf.key] = encoded
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// Environ encodes the model like Marshal, in the KEY=value form of
+// os.Environ sorted by key, ready for exec.Cmd.Env
+func Environ(model any) ([]string, error) {
+	values, err := Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return environFromMap(values), nil
+}
+
+// structValue returns the struct the model is or points to
+func structValue(model any) (reflect.Value, error) {
+	value := reflect.Indirect(reflect.ValueOf(model))
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("model must be a struct or a pointer to a struct")
+	}
+
+	return value, nil
+}
+
+func marshalValue(value reflect.Value) (string, error) {
+	if value.Type() == durationType {
+		return time.Duration(value.Int()).String(), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Slice:
+		items := make([]string, value.Len())
+		for i := range items {
+			item, err := marshalScalar(value.Index(i))
+			if err != nil {
+				return "", err
+			}
+			if strings.Contains(item, itemSeparator) || (item == "" && value.Len() == 1) {
+				return "", fmt.Errorf("slice item %q cannot be read back", item)
+			}
+			items[i] = item
+		}
+
+		return strings.Join(items, itemSeparator), nil
+
+	case reflect.Map:
+		pairs := make([]string, 0, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			key, err := marshalScalar(iter.Key())
+			if err != nil {
+				return "", err
+			}
+
+			item, err := marshalScalar(iter.Value())
+			if err != nil {
+				return "", err
+			}
+
+			if strings.ContainsAny(key+item, itemSeparator+keyValueSeparator) {
+				return "", fmt.Errorf("map pair %q:%q cannot be read back", key, item)
+			}
+			pairs = append(pairs, key+keyValueSeparator+item)
+		}
+		sort.Strings(pairs)
+
+		return strings.Join(pairs, itemSeparator), nil
+
+	default:
+		return marshalScalar(value)
+	}
+}
+
+func marshalScalar(value reflect.Value) (string, error) {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	default:
+		return "", fmt.Errorf("unsupported type: %s", value.Type())
+	}
+}
@@ -0,0 +1,109 @@
+package goenv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	type DBConfig struct {
+		Host    string
+		Port    int
+		Timeout time.Duration
+	}
+
+	type ConfigModel struct {
+		WebsiteURL       string             `env:"websiteUrl"`
+		FormulaConstants map[string]float64 `default:"pi:3.14"`
+		Proxies          []string
+		Debug            bool
+		Ignored          string `env:"-"`
+		Database         DBConfig
+		Server           DBConfig `env:"-"`
+	}
+
+	config := ConfigModel{
+		WebsiteURL:       "https://example.com",
+		FormulaConstants: map[string]float64{"pi": 3.14, "e": 2.71828},
+		Proxies:          []string{"proxy1", "proxy2"},
+		Debug:            true,
+		Ignored:          "ignored",
+		Database: DBConfig{
+			Host:    "localhost",
+			Port:    5432,
+			Timeout: 1500 * time.Millisecond,
+		},
+		Server: DBConfig{
+			Host: "0.0.0.0",
+			Port: 8080,
+		},
+	}
+
+	t.Run("TestMarshal_WithNestedStructs", func(t *testing.T) {
+		values, err := Marshal(config)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"websiteUrl":        "https://example.com",
+			"FORMULA_CONSTANTS": "e:2.71828,pi:3.14",
+			"PROXIES":           "proxy1,proxy2",
+			"DEBUG":             "true",
+			"DATABASE_HOST":     "localhost",
+			"DATABASE_PORT":     "5432",
+			"DATABASE_TIMEOUT":  "1.5s",
+			"HOST":              "0.0.0.0",
+			"PORT":              "8080",
+			"TIMEOUT":           "0s",
+		}, values)
+
+		env, err := Environ(&config)
+		require.NoError(t, err)
+		assert.Contains(t, env, "DATABASE_TIMEOUT=1.5s")
+	})
+
+	t.Run("TestMarshal_RoundTripsThroughLoad", func(t *testing.T) {
+		values, err := Marshal(&config)
+		require.NoError(t, err)
+
+		loader, err := NewLoader(WithReader(MapReader(values)))
+		require.NoError(t, err)
+
+		loaded := &ConfigModel{}
+
+		err = loader.Load(loaded)
+		require.NoError(t, err)
+
+		expected := config
+		expected.Ignored = ""
+
+		assert.Equal(t, &expected, loaded)
+	})
+
+	t.Run("TestMarshal_WhenValueCannotBeReadBack", func(t *testing.T) {
+		_, err := Marshal(ConfigModel{Proxies: []string{"a,b"}})
+		assert.Error(t, err)
+
+		_, err = Marshal(ConfigModel{FormulaConstants: map[string]float64{"a:b": 1}})
+		assert.Error(t, err)
+	})
+
+	t.Run("TestMarshal_WhenKeysCollide", func(t *testing.T) {
+		type ConfigModel struct {
+			Host     string
+			Database struct {
+				Host string
+			} `env:"-"`
+		}
+
+		_, err := Marshal(ConfigModel{})
+		assert.Error(t, err)
+	})
+
+	t.Run("TestMarshal_WhenModelIsNotStruct", func(t *testing.T) {
+		_, err := Marshal("test")
+		assert.Error(t, err)
+	})
+}
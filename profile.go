@@ -0,0 +1,61 @@
+package goenv
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// WithProfile layers the dotenv files of the profile named by envKey beneath
+// the Loader's current reader. The profile is looked up through that reader,
+// so WithReader must come first when it is used. Files are read from dir in
+// the order .env, .env.<profile>, .env.local and .env.<profile>.local, each
+// overriding the previous ones, and missing files are skipped. The files
+// that were found are reported by Files.
+func WithProfile(envKey string, dir string) Option {
+	return func(l *Loader) error {
+		profile, _ := l.reader.LookupEnv(envKey)
+		if strings.ContainsAny(profile, `/\`) {
+			return fmt.Errorf("invalid profile %s in %s", profile, envKey)
+		}
+
+		names := []string{".env"}
+		if profile != "" {
+			names = append(names, ".env."+profile)
+		}
+		names = append(names, ".env.local")
+		if profile != "" {
+			names = append(names, ".env."+profile+".local")
+		}
+
+		var files []string
+		var readers []EnvReader
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+
+			reader, err := NewDotenvReader(path)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			files = append(files, path)
+			readers = append([]EnvReader{reader}, readers...)
+		}
+
+		l.reader = NewChainReader(append([]EnvReader{l.reader}, readers...)...)
+		l.files = append(l.files, files...)
+
+		return nil
+	}
+}
+
+// Files returns the files applied by WithProfile, from the lowest to the
+// highest precedence
+func (l *Loader) Files() []string {
+	return l.files
+}
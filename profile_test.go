@@ -0,0 +1,88 @@
+package goenv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metinorak/goenv/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithProfile(t *testing.T) {
+	type ConfigModel struct {
+		WebsiteURL string
+		LogLevel   string
+		DBHost     string `env:"DB_HOST"`
+		DBPort     int    `env:"DB_PORT"`
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		".env":               "WEBSITE_URL=https://example.com\nLOG_LEVEL=info\nDB_HOST=localhost\nDB_PORT=5432\n",
+		".env.staging":       "WEBSITE_URL=https://staging.example.com\nLOG_LEVEL=debug\n",
+		".env.staging.local": "DB_HOST=db.staging.internal\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	t.Run("TestWithProfile_WithProfileFiles", func(t *testing.T) {
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv("APP_ENV").Return("staging", true)
+		mockEnvReader.EXPECT().LookupEnv("LOG_LEVEL").Return("warn", true)
+		mockEnvReader.EXPECT().LookupEnv(gomock.Any()).Return("", false).AnyTimes()
+
+		loader, err := NewLoader(WithReader(mockEnvReader), WithProfile("APP_ENV", dir))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			filepath.Join(dir, ".env"),
+			filepath.Join(dir, ".env.staging"),
+			filepath.Join(dir, ".env.staging.local"),
+		}, loader.Files())
+
+		config := &ConfigModel{}
+
+		err = loader.Load(config)
+		assert.NoError(t, err)
+
+		expected := &ConfigModel{
+			WebsiteURL: "https://staging.example.com",
+			LogLevel:   "warn",
+			DBHost:     "db.staging.internal",
+			DBPort:     5432,
+		}
+
+		assert.Equal(t, expected, config)
+	})
+
+	t.Run("TestWithProfile_WhenProfileIsNotSet", func(t *testing.T) {
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv(gomock.Any()).Return("", false).AnyTimes()
+
+		loader, err := NewLoader(WithReader(mockEnvReader), WithProfile("APP_ENV", dir))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{filepath.Join(dir, ".env")}, loader.Files())
+	})
+
+	t.Run("TestWithProfile_WhenProfileIsNotValid", func(t *testing.T) {
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv("APP_ENV").Return("../prod", true)
+
+		_, err := NewLoader(WithReader(mockEnvReader), WithProfile("APP_ENV", dir))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,149 @@
+package goenv
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Resolver resolves references like file:///run/secrets/db, found in the
+// values of fields tagged with resolve:"true", to the value they point to.
+// It receives the reference without its scheme:// prefix.
+type Resolver interface {
+	Resolve(reference string) (string, error)
+}
+
+// ResolverFunc adapts a function to the Resolver interface
+type ResolverFunc func(reference string) (string, error)
+
+func (f ResolverFunc) Resolve(reference string) (string, error) {
+	return f(reference)
+}
+
+type ErrResolveEnvValue struct {
+	Key string
+	Err error
+}
+
+func (e *ErrResolveEnvValue) Error() string {
+	return fmt.Sprintf("failed to resolve environment variable %s: %s", e.Key, e.Err)
+}
+
+func (e *ErrResolveEnvValue) Unwrap() error {
+	return e.Err
+}
+
+// WithResolver registers the resolver for references with the given scheme,
+// replacing the built-in file, env and base64 resolvers when it uses one of
+// their schemes
+func WithResolver(scheme string, resolver Resolver) Option {
+	return func(l *Loader) error {
+		if scheme == "" || resolver == nil {
+			return fmt.Errorf("resolver scheme and resolver must not be empty")
+		}
+
+		l.resolvers[scheme] = resolver
+		return nil
+	}
+}
+
+// WithResolveAll resolves references in the values of every field, not only
+// the ones tagged with resolve:"true"
+func WithResolveAll() Option {
+	return func(l *Loader) error {
+		l.resolveAll = true
+		return nil
+	}
+}
+
+// builtinResolvers are the built-in resolvers that do not look variables up,
+// shared by every Loader and by Resolve:
+//   - file:///path, replaced by the contents of the file without its
+//     trailing newline
+//   - base64://data, replaced by the decoded data
+var builtinResolvers = map[string]Resolver{
+	"file":   ResolverFunc(resolveFile),
+	"base64": ResolverFunc(resolveBase64),
+}
+
+// envScheme is the scheme of references to other variables of the reader,
+// like env://NAME
+const envScheme = "env"
+
+// registerBuiltinResolvers registers the built-in resolvers, with the env
+// resolver looking variables up in the Loader's reader
+func (l *Loader) registerBuiltinResolvers() {
+	for scheme, resolver := range builtinResolvers {
+		l.resolvers[scheme] = resolver
+	}
+
+	l.resolvers[envScheme] = ResolverFunc(func(reference string) (string, error) {
+		return resolveEnv(l.reader, reference)
+	})
+}
+
+func resolveFile(reference string) (string, error) {
+	data, err := os.ReadFile(reference)
+	if err != nil {
+		return "", err
+	}
+
+	return trimTrailingNewline(string(data)), nil
+}
+
+func resolveEnv(reader EnvReader, reference string) (string, error) {
+	value, ok := reader.LookupEnv(reference)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", reference)
+	}
+
+	return value, nil
+}
+
+func resolveBase64(reference string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(reference)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// Resolve returns the value a reference points to with the built-in
+// resolvers, looking env:// references up in the reader, as Load does for
+// fields tagged resolve:"true". It is called by the loaders generated by
+// goenv gen-loader.
+func Resolve(reader EnvReader, value string) (string, error) {
+	scheme, reference, ok := strings.Cut(value, "://")
+	if !ok {
+		return value, nil
+	}
+
+	if scheme == envScheme {
+		return resolveEnv(reader, reference)
+	}
+
+	resolver, ok := builtinResolvers[scheme]
+	if !ok {
+		return value, nil
+	}
+
+	return resolver.Resolve(reference)
+}
+
+// resolve returns the value a reference points to, or the value itself when
+// it is not a reference with a registered scheme
+func (l *Loader) resolve(value string) (string, error) {
+	scheme, reference, ok := strings.Cut(value, "://")
+	if !ok {
+		return value, nil
+	}
+
+	resolver, ok := l.resolvers[scheme]
+	if !ok {
+		return value, nil
+	}
+
+	return resolver.Resolve(reference)
+}
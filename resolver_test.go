@@ -0,0 +1,114 @@
+package goenv
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metinorak/goenv/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvers(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "db_password")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-secret\n"), 0o600))
+
+	t.Run("TestResolvers_WithBuiltinSchemes", func(t *testing.T) {
+		type ConfigModel struct {
+			DBPassword string `env:"DB_PASSWORD" resolve:"true"`
+			APIToken   string `env:"API_TOKEN" resolve:"true"`
+			Greeting   string `resolve:"true"`
+			WebsiteURL string `resolve:"true"`
+			Plain      string
+		}
+
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv("DB_PASSWORD").Return("file://"+secretFile, true)
+		mockEnvReader.EXPECT().LookupEnv("API_TOKEN").Return("env://LEGACY_API_TOKEN", true)
+		mockEnvReader.EXPECT().LookupEnv("LEGACY_API_TOKEN").Return("legacy-token", true)
+		mockEnvReader.EXPECT().LookupEnv("GREETING").Return("base64://aGVsbG8=", true)
+		mockEnvReader.EXPECT().LookupEnv("WEBSITE_URL").Return("https://example.com", true)
+		mockEnvReader.EXPECT().LookupEnv("PLAIN").Return("base64://aGVsbG8=", true)
+
+		loader, err := NewLoader(WithReader(mockEnvReader))
+		require.NoError(t, err)
+
+		config := &ConfigModel{}
+
+		err = loader.Load(config)
+		assert.NoError(t, err)
+
+		expected := &ConfigModel{
+			DBPassword: "file-secret",
+			APIToken:   "legacy-token",
+			Greeting:   "hello",
+			WebsiteURL: "https://example.com",
+			Plain:      "base64://aGVsbG8=",
+		}
+
+		assert.Equal(t, expected, config)
+	})
+
+	t.Run("TestResolvers_WithCustomResolverAndResolveAll", func(t *testing.T) {
+		type ConfigModel struct {
+			DBPassword string `env:"DB_PASSWORD"`
+			MaxConns   int    `default:"vault://secret/db#max_conns"`
+		}
+
+		vaultResolver := ResolverFunc(func(reference string) (string, error) {
+			switch reference {
+			case "secret/db#password":
+				return "vault-secret", nil
+			case "secret/db#max_conns":
+				return "25", nil
+			}
+			return "", errors.New("secret not found")
+		})
+
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv("DB_PASSWORD").Return("vault://secret/db#password", true)
+		mockEnvReader.EXPECT().LookupEnv("MAX_CONNS").Return("", false)
+
+		loader, err := NewLoader(WithReader(mockEnvReader), WithResolver("vault", vaultResolver), WithResolveAll())
+		require.NoError(t, err)
+
+		config := &ConfigModel{}
+
+		err = loader.Load(config)
+		assert.NoError(t, err)
+
+		assert.Equal(t, &ConfigModel{DBPassword: "vault-secret", MaxConns: 25}, config)
+	})
+
+	t.Run("TestResolvers_WhenReferenceCannotBeResolved", func(t *testing.T) {
+		type ConfigModel struct {
+			DBPassword string `env:"DB_PASSWORD" resolve:"true"`
+		}
+
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv("DB_PASSWORD").Return("file://"+strings.TrimSuffix(secretFile, "password")+"missing", true)
+
+		loader, err := NewLoader(WithReader(mockEnvReader))
+		require.NoError(t, err)
+
+		err = loader.Load(&ConfigModel{})
+
+		var resolveErr *ErrResolveEnvValue
+		require.True(t, errors.As(err, &resolveErr))
+		assert.Equal(t, "DB_PASSWORD", resolveErr.Key)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
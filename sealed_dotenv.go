@@ -0,0 +1,226 @@
+package goenv
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/metinorak/goenv/internal/dotenv"
+)
+
+// sealedPrefix marks the values of a dotenv file encrypted by Seal
+const sealedPrefix = "enc:v1:"
+
+// ErrInvalidKey is returned when the key for sealed dotenv files is not 32
+// bytes, encoded in base64 or hex
+var ErrInvalidKey = errors.New("key must be 32 bytes encoded in base64 or hex")
+
+// LoadKey loads the key of sealed dotenv files from $GOENV_KEY, or from the
+// file named by $GOENV_KEY_FILE. The key is 32 random bytes encoded in base64
+// or hex, like the output of openssl rand -base64 32.
+func LoadKey() ([]byte, error) {
+	if encoded, ok := os.LookupEnv("GOENV_KEY"); ok && encoded != "" {
+		return decodeKey(encoded)
+	}
+
+	if path, ok := os.LookupEnv("GOENV_KEY_FILE"); ok && path != "" {
+		return LoadKeyFile(path)
+	}
+
+	return nil, fmt.Errorf("neither GOENV_KEY nor GOENV_KEY_FILE is set")
+}
+
+// LoadKeyFile loads the key of sealed dotenv files from a file
+func LoadKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeKey(string(data))
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		key, err = hex.DecodeString(encoded)
+	}
+	if err != nil || len(key) != 32 {
+		return nil, ErrInvalidKey
+	}
+
+	return key, nil
+}
+
+// NewSealedDotenvReader reads a dotenv file whose values may have been
+// encrypted by Seal, decrypting them with the key
+func NewSealedDotenvReader(path string, key []byte) (MapReader, error) {
+	return readDocumentFile(path, func(data []byte) (MapReader, error) {
+		values, err := parseDotenv(data)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, value := range values {
+			if values[name], err = unsealValue(key, name, value); err != nil {
+				return nil, err
+			}
+		}
+
+		return values, nil
+	})
+}
+
+// Seal encrypts the values of a dotenv file in place with AES-GCM, keeping
+// the keys and comments in plaintext so that changes stay reviewable.
+// Values that are already sealed are left as they are.
+func Seal(path string, key []byte) error {
+	return rewriteDotenv(path, func(line *dotenv.Line) error {
+		if strings.HasPrefix(line.Value, sealedPrefix) {
+			return nil
+		}
+
+		sealed, err := sealValue(key, line.Key, line.Value)
+		if err != nil {
+			return err
+		}
+
+		line.Value = sealed
+		return nil
+	})
+}
+
+// Unseal decrypts the values of a dotenv file sealed by Seal in place
+func Unseal(path string, key []byte) error {
+	return rewriteDotenv(path, func(line *dotenv.Line) error {
+		value, err := unsealValue(key, line.Key, line.Value)
+		if err != nil {
+			return err
+		}
+
+		line.Value = value
+		return nil
+	})
+}
+
+// rewriteDotenv applies fn to every assignment of the file and replaces the
+// file atomically, leaving the lines that fn does not change as written
+func rewriteDotenv(path string, fn func(line *dotenv.Line) error) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines, err := dotenv.Parse(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		texts[i] = line.Text
+
+		if line.Key == "" {
+			continue
+		}
+
+		value := line.Value
+		if err := fn(&line); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		if line.Value != value {
+			texts[i] = line.String()
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(strings.Join(texts, "\n")); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// sealValue encrypts the value, authenticating the name of its key so that
+// sealed values cannot be moved to other keys
+func sealValue(key []byte, name string, value string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(value), []byte(name))
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// unsealValue decrypts a value sealed by sealValue, returning other values
+// as they are
+func unsealValue(key []byte, name string, value string) (string, error) {
+	if !strings.HasPrefix(value, sealedPrefix) {
+		return value, nil
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("sealed value of %s is malformed", name)
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s, is the key right?", name)
+	}
+
+	return string(plaintext), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, ErrInvalidKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,105 @@
+package goenv
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealedDotenv(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	content := "# database settings\nexport DB_HOST=localhost\nDB_PASSWORD=\"p@ss word\"\nCERTIFICATE=\"line1\nline2\"\n"
+
+	t.Run("TestSealedDotenv_WithSealAndUnseal", func(t *testing.T) {
+		path := writeFile(t, ".env", content)
+
+		require.NoError(t, Seal(path, key))
+
+		sealed, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		lines := strings.Split(string(sealed), "\n")
+		assert.Equal(t, "# database settings", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "export DB_HOST="+sealedPrefix))
+		assert.True(t, strings.HasPrefix(lines[2], "DB_PASSWORD="+sealedPrefix))
+		assert.NotContains(t, string(sealed), "p@ss word")
+
+		// sealing again leaves sealed values as they are
+		require.NoError(t, Seal(path, key))
+		resealed, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, string(sealed), string(resealed))
+
+		reader, err := NewSealedDotenvReader(path, key)
+		require.NoError(t, err)
+		assert.Equal(t, MapReader{
+			"DB_HOST":     "localhost",
+			"DB_PASSWORD": "p@ss word",
+			"CERTIFICATE": "line1\nline2",
+		}, reader)
+
+		require.NoError(t, Unseal(path, key))
+
+		unsealed, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `# database settings
+export DB_HOST=localhost
+DB_PASSWORD="p@ss word"
+CERTIFICATE="line1\nline2"
+`, string(unsealed))
+	})
+
+	t.Run("TestSealedDotenv_WhenKeyIsWrong", func(t *testing.T) {
+		path := writeFile(t, ".env", content)
+		require.NoError(t, Seal(path, key))
+
+		_, err := NewSealedDotenvReader(path, []byte("fedcba9876543210fedcba9876543210"))
+		assert.Error(t, err)
+
+		assert.ErrorIs(t, Seal(writeFile(t, ".env", content), []byte("short")), ErrInvalidKey)
+	})
+
+	t.Run("TestSealedDotenv_WhenValueIsMovedToAnotherKey", func(t *testing.T) {
+		path := writeFile(t, ".env", "A=first\nB=second\n")
+		require.NoError(t, Seal(path, key))
+
+		sealed, err := parseDotenv(mustReadFile(t, path))
+		require.NoError(t, err)
+
+		swapped := writeFile(t, ".env", "A="+sealed["B"]+"\n")
+		_, err = NewSealedDotenvReader(swapped, key)
+		assert.Error(t, err)
+	})
+
+	t.Run("TestSealedDotenv_WithLoadKey", func(t *testing.T) {
+		t.Setenv("GOENV_KEY", base64.StdEncoding.EncodeToString(key))
+
+		loaded, err := LoadKey()
+		require.NoError(t, err)
+		assert.Equal(t, key, loaded)
+
+		keyFile := filepath.Join(t.TempDir(), "key")
+		require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(key)+"\n"), 0o600))
+		t.Setenv("GOENV_KEY", "")
+		t.Setenv("GOENV_KEY_FILE", keyFile)
+
+		loaded, err = LoadKey()
+		require.NoError(t, err)
+		assert.Equal(t, key, loaded)
+	})
+}
+
+func mustReadFile(t *testing.T, path string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return data
+}
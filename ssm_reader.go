@@ -0,0 +1,172 @@
+package goenv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// SSMConfig configures an SSMReader
+type SSMConfig struct {
+	// Path is the parameter hierarchy read recursively, like /service/env/
+	Path string
+
+	// Region of the parameters, $AWS_REGION or $AWS_DEFAULT_REGION by default
+	Region string
+
+	// Endpoint overrides https://ssm.<region>.amazonaws.com, for example to
+	// talk to a local stand-in
+	Endpoint string
+
+	// Credentials the requests are signed with, $AWS_ACCESS_KEY_ID,
+	// $AWS_SECRET_ACCESS_KEY and $AWS_SESSION_TOKEN by default
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// DisableDecryption returns SecureString parameters encrypted
+	DisableDecryption bool
+
+	// KeyFunc maps a parameter name to the key it is looked up with. By
+	// default the path is removed and the rest is named like nested struct
+	// fields, so /service/env/database/host is read as DATABASE_HOST.
+	KeyFunc func(name string) string
+
+	// HTTPClient is used for the requests, http.DefaultClient by default
+	HTTPClient *http.Client
+}
+
+// SSMReader reads a parameter hierarchy from AWS Systems Manager Parameter
+// Store, or any service speaking its protocol. The parameters are read once
+// when the reader is created, in batches of ten per request.
+type SSMReader struct {
+	values MapReader
+}
+
+type ssmParameter struct {
+	Name  string
+	Value string
+}
+
+// NewSSMReader reads every parameter under the configured path
+func NewSSMReader(ctx context.Context, config SSMConfig) (*SSMReader, error) {
+	if config.Region == "" {
+		config.Region = os.Getenv("AWS_REGION")
+	}
+	if config.Region == "" {
+		config.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if config.Region == "" {
+		return nil, fmt.Errorf("AWS region is not set")
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://ssm." + config.Region + ".amazonaws.com"
+	}
+	if config.AccessKeyID == "" {
+		config.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		config.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		config.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("AWS credentials are not set")
+	}
+	if config.KeyFunc == nil {
+		config.KeyFunc = func(name string) string {
+			return kvKey(config.Path, name)
+		}
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+
+	values := make(MapReader)
+	nextToken := ""
+	for {
+		parameters, token, err := getParametersByPath(ctx, config, nextToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read SSM parameters under %s: %w", config.Path, err)
+		}
+
+		for _, parameter := range parameters {
+			if key := config.KeyFunc(parameter.Name); key != "" {
+				values[key] = parameter.Value
+			}
+		}
+
+		if token == "" {
+			break
+		}
+		nextToken = token
+	}
+
+	return &SSMReader{values: values}, nil
+}
+
+func (r *SSMReader) LookupEnv(key string) (string, bool) {
+	return r.values.LookupEnv(key)
+}
+
+func (r *SSMReader) Environ() []string {
+	return r.values.Environ()
+}
+
+func getParametersByPath(ctx context.Context, config SSMConfig, nextToken string) ([]ssmParameter, string, error) {
+	body := map[string]any{
+		"Path":           config.Path,
+		"Recursive":      true,
+		"WithDecryption": !config.DisableDecryption,
+		"MaxResults":     10,
+	}
+	if nextToken != "" {
+		body["NextToken"] = nextToken
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(config.Endpoint, "/")+"/", bytes.NewReader(payload))
+	if err != nil {
+		return nil, "", err
+	}
+	request.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	request.Header.Set("X-Amz-Target", "AmazonSSM.GetParametersByPath")
+
+	signV4(request, payload, awsCredentials{
+		AccessKeyID:     config.AccessKeyID,
+		SecretAccessKey: config.SecretAccessKey,
+		SessionToken:    config.SessionToken,
+	}, config.Region, "ssm", time.Now())
+
+	response, err := config.HTTPClient.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		var ssmError struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(response.Body).Decode(&ssmError)
+
+		return nil, "", fmt.Errorf("SSM responded with %s: %s %s", response.Status, ssmError.Type, ssmError.Message)
+	}
+
+	var result struct {
+		Parameters []ssmParameter
+		NextToken  string
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, "", err
+	}
+
+	return result.Parameters, result.NextToken, nil
+}
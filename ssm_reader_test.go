@@ -0,0 +1,75 @@
+package goenv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignV4(t *testing.T) {
+	// the get-vanilla case of the AWS Signature Version 4 test suite
+	request, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+
+	signV4(request, nil, awsCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, "+
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31", request.Header.Get("Authorization"))
+}
+
+func TestSSMReader(t *testing.T) {
+	pages := map[string]string{
+		"": `{"Parameters":[{"Name":"/service/env/database/host","Type":"String","Value":"db.internal"}],"NextToken":"page2"}`,
+		"page2": `{"Parameters":[{"Name":"/service/env/database/password","Type":"SecureString","Value":"secret"},` +
+			`{"Name":"/service/env/websiteUrl","Type":"String","Value":"https://example.com"}]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AmazonSSM.GetParametersByPath", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "session-token", r.Header.Get("X-Amz-Security-Token"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/ssm/aws4_request")
+
+		var body struct {
+			Path           string
+			Recursive      bool
+			WithDecryption bool
+			NextToken      string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "/service/env/", body.Path)
+		assert.True(t, body.Recursive)
+		assert.True(t, body.WithDecryption)
+
+		_, _ = w.Write([]byte(pages[body.NextToken]))
+	}))
+	defer server.Close()
+
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SECRET")
+	t.Setenv("AWS_SESSION_TOKEN", "session-token")
+
+	reader, err := NewSSMReader(context.Background(), SSMConfig{
+		Path:     "/service/env/",
+		Endpoint: server.URL,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"DATABASE_HOST=db.internal",
+		"DATABASE_PASSWORD=secret",
+		"WEBSITE_URL=https://example.com",
+	}, reader.Environ())
+}
@@ -0,0 +1,297 @@
+package goenv
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationRule is a rule of a validate tag, like oneof=debug info or min=1.
+// Rules are separated by commas:
+//
+//   - oneof=a b c: the value, or each item of a slice, is one of the options
+//   - min=N and max=N: numbers and durations are at least or at most N,
+//     strings, slices and maps have at least or at most N characters or items
+//   - regex=EXPR: the value, or each item of a slice, matches the regular
+//     expression. It takes the rest of the tag, commas included, so it must
+//     come last.
+//
+// Other rules, like the required or email rules of validator packages
+// sharing the tag, are ignored.
+type ValidationRule struct {
+	Name string
+	Arg  string
+}
+
+func (r ValidationRule) String() string {
+	return r.Name + "=" + r.Arg
+}
+
+type ErrValidation struct {
+	Key   string
+	Value string
+	Rule  ValidationRule
+}
+
+func (e *ErrValidation) Error() string {
+	return fmt.Sprintf("environment variable %s does not satisfy %s: %s", e.Key, e.Rule, e.Value)
+}
+
+const regexRule = "regex"
+
+// compiledRule is a validation rule ready to check values, with its regular
+// expression compiled and its oneof options split
+type compiledRule struct {
+	ValidationRule
+
+	regexp  *regexp.Regexp
+	options []string
+}
+
+// ValidationRules are the rules of a validate tag, parsed once to check any
+// number of values. The loaders generated by goenv gen-loader keep them in
+// package variables.
+type ValidationRules struct {
+	rules []compiledRule
+}
+
+// CompileValidationRules parses the rules of a validate tag
+func CompileValidationRules(tag string) (*ValidationRules, error) {
+	rules, err := compileRules(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValidationRules{rules: rules}, nil
+}
+
+// MustCompileValidationRules is like CompileValidationRules but panics when
+// the tag is not valid
+func MustCompileValidationRules(tag string) *ValidationRules {
+	rules, err := CompileValidationRules(tag)
+	if err != nil {
+		panic(err)
+	}
+
+	return rules
+}
+
+// Validate checks a value loaded from the variable key against the rules, as
+// Load does once a field is parsed
+func (r *ValidationRules) Validate(key string, value any) error {
+	return r.validate(key, reflect.ValueOf(value))
+}
+
+func parseValidationRules(tag string) ([]ValidationRule, error) {
+	compiled, err := compileRules(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []ValidationRule
+	for _, rule := range compiled {
+		rules = append(rules, rule.ValidationRule)
+	}
+
+	return rules, nil
+}
+
+func compileRules(tag string) ([]compiledRule, error) {
+	if tag == "" {
+		return nil, nil
+	}
+
+	var rules []compiledRule
+	parts := strings.Split(tag, ",")
+	for i, part := range parts {
+		name, arg, _ := strings.Cut(part, "=")
+
+		switch name {
+		case regexRule:
+			if arg == "" {
+				return nil, fmt.Errorf("invalid validation rule: %s", part)
+			}
+			arg = strings.Join(append([]string{arg}, parts[i+1:]...), ",")
+			compiled, err := regexp.Compile(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid validation rule %s: %w", part, err)
+			}
+			return append(rules, compiledRule{
+				ValidationRule: ValidationRule{Name: name, Arg: arg},
+				regexp:         compiled,
+			}), nil
+
+		case "oneof", "min", "max":
+			if arg == "" {
+				return nil, fmt.Errorf("invalid validation rule: %s", part)
+			}
+			rules = append(rules, compiledRule{
+				ValidationRule: ValidationRule{Name: name, Arg: arg},
+				options:        strings.Fields(arg),
+			})
+		}
+	}
+
+	return rules, nil
+}
+
+// validationRulesCache holds the compiled rules of the validate tags seen by
+// Load and Validate, keyed by tag
+var validationRulesCache sync.Map
+
+// validateField checks the loaded value of the field against the rules of
+// its validate tag
+func validateField(key string, field structField, value reflect.Value) error {
+	return validateValue(key, field.getValidationRules(), value)
+}
+
+// Validate checks a value loaded from the variable key against the rules of
+// a validate tag, as Load does once a field is parsed. The rules of each tag
+// are parsed once.
+func Validate(key string, rules string, value any) error {
+	return validateValue(key, rules, reflect.ValueOf(value))
+}
+
+func validateValue(key string, tag string, value reflect.Value) error {
+	if tag == "" {
+		return nil
+	}
+
+	cached, ok := validationRulesCache.Load(tag)
+	if !ok {
+		rules, err := CompileValidationRules(tag)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
+		}
+		cached, _ = validationRulesCache.LoadOrStore(tag, rules)
+	}
+
+	return cached.(*ValidationRules).validate(key, value)
+}
+
+func (r *ValidationRules) validate(key string, value reflect.Value) error {
+	for _, rule := range r.rules {
+		ok, err := checkRule(rule, value)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
+		}
+
+		if !ok {
+			encoded, _ := marshalValue(value)
+			return &ErrValidation{
+				Key:   key,
+				Value: encoded,
+				Rule:  rule.ValidationRule,
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkRule(rule compiledRule, value reflect.Value) (bool, error) {
+	switch rule.Name {
+	case "min", "max":
+		actual, limit, err := ruleBounds(rule.ValidationRule, value)
+		if err != nil {
+			return false, err
+		}
+
+		if rule.Name == "min" {
+			return actual >= limit, nil
+		}
+		return actual <= limit, nil
+
+	default:
+		items, err := ruleItems(value)
+		if err != nil {
+			return false, fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+
+		for _, item := range items {
+			var ok bool
+			if rule.regexp != nil {
+				ok = rule.regexp.MatchString(item)
+			} else {
+				ok = contains(rule.options, item)
+			}
+
+			if !ok {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}
+}
+
+// ruleBounds returns what min and max rules compare for the value, with the
+// limit of the rule
+func ruleBounds(rule ValidationRule, value reflect.Value) (float64, float64, error) {
+	if value.Type() == durationType {
+		limit, err := time.ParseDuration(rule.Arg)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid duration in %s: %w", rule, err)
+		}
+		return float64(value.Int()), float64(limit), nil
+	}
+
+	limit, err := strconv.ParseFloat(rule.Arg, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid number in %s: %w", rule, err)
+	}
+
+	switch value.Kind() {
+	case reflect.Int:
+		return float64(value.Int()), limit, nil
+	case reflect.Float64:
+		return value.Float(), limit, nil
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), limit, nil
+	case reflect.Slice, reflect.Map:
+		return float64(value.Len()), limit, nil
+	default:
+		return 0, 0, fmt.Errorf("rule %s is not supported for %s", rule.Name, value.Type())
+	}
+}
+
+// ruleItems returns the values that oneof and regex rules check
+func ruleItems(value reflect.Value) ([]string, error) {
+	switch value.Kind() {
+	case reflect.Slice:
+		items := make([]string, value.Len())
+		for i := range items {
+			item, err := marshalScalar(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+
+	case reflect.Map:
+		return nil, fmt.Errorf("not supported for %s", value.Type())
+
+	default:
+		item, err := marshalValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return []string{item}, nil
+	}
+}
+
+func contains(options []string, str string) bool {
+	for _, option := range options {
+		if option == str {
+			return true
+		}
+	}
+
+	return false
+}
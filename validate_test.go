@@ -0,0 +1,87 @@
+package goenv
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_WithValidationRules(t *testing.T) {
+	type ConfigModel struct {
+		LogLevel string        `validate:"oneof=debug info warn"`
+		Port     int           `validate:"min=1,max=65535"`
+		Timeout  time.Duration `validate:"max=1m"`
+		Hosts    []string      `validate:"min=1,regex=^[a-z]+(,[a-z]+)?$"`
+	}
+
+	load := func(values MapReader) error {
+		loader, err := NewLoader(WithReader(values))
+		require.NoError(t, err)
+
+		return loader.Load(&ConfigModel{})
+	}
+
+	valid := MapReader{"LOG_LEVEL": "info", "PORT": "8080", "TIMEOUT": "30s", "HOSTS": "a,b"}
+	assert.NoError(t, load(valid))
+
+	invalid := []MapReader{
+		{"LOG_LEVEL": "trace"},
+		{"PORT": "0"},
+		{"TIMEOUT": "2m"},
+		{"HOSTS": "a,B"},
+	}
+	for _, values := range invalid {
+		err := load(values)
+
+		var validationErr *ErrValidation
+		assert.True(t, errors.As(err, &validationErr), "%v: %v", values, err)
+	}
+
+	rule, err := parseValidationRules("min=1,regex=^a,b$")
+	require.NoError(t, err)
+	assert.Equal(t, []ValidationRule{{Name: "min", Arg: "1"}, {Name: "regex", Arg: "^a,b$"}}, rule)
+
+	assert.NoError(t, Validate("NAME", "max=3", "héé"))
+	assert.Error(t, Validate("NAME", "max=3", "héér"))
+
+	_, err = parseValidationRules("min=")
+	assert.Error(t, err)
+}
+
+func TestLoad_WithOtherValidatorRules(t *testing.T) {
+	type ConfigModel struct {
+		Email string `validate:"required,email"`
+		Name  string `validate:"required,max=5"`
+	}
+
+	loader, err := NewLoader(WithReader(MapReader{"EMAIL": "a@b.c", "NAME": "alice"}))
+	require.NoError(t, err)
+
+	var config ConfigModel
+	require.NoError(t, loader.Load(&config))
+	assert.Equal(t, "a@b.c", config.Email)
+
+	rules, err := parseValidationRules("required,email,max=5")
+	require.NoError(t, err)
+	assert.Equal(t, []ValidationRule{{Name: "max", Arg: "5"}}, rules)
+}
+
+func TestCompileValidationRules(t *testing.T) {
+	rules, err := CompileValidationRules("min=2,regex=^[a-z]+$")
+	require.NoError(t, err)
+
+	assert.NoError(t, rules.Validate("NAME", "abc"))
+
+	var validationErr *ErrValidation
+	assert.True(t, errors.As(rules.Validate("NAME", "a"), &validationErr))
+	assert.Equal(t, ValidationRule{Name: "min", Arg: "2"}, validationErr.Rule)
+	assert.True(t, errors.As(rules.Validate("NAME", "ABC"), &validationErr))
+	assert.Equal(t, ValidationRule{Name: "regex", Arg: "^[a-z]+$"}, validationErr.Rule)
+
+	_, err = CompileValidationRules("regex=[")
+	assert.Error(t, err)
+	assert.Panics(t, func() { MustCompileValidationRules("regex=[") })
+}
@@ -0,0 +1,354 @@
+package goenv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VaultConfig configures a VaultReader
+type VaultConfig struct {
+	// Address of the Vault server, $VAULT_ADDR by default
+	Address string
+
+	// Token used to authenticate, $VAULT_TOKEN by default. When it is empty
+	// the reader logs in with AppRole using RoleID and SecretID.
+	Token    string
+	RoleID   string
+	SecretID string
+
+	// AppRoleMount is the mount of the AppRole auth method, approle by default
+	AppRoleMount string
+
+	// Mount is the mount of the KV v2 secrets engine, secret by default
+	Mount string
+
+	// Paths are the secrets read from the mount. When several secrets have
+	// the same key, the one in the latest path is used.
+	Paths []string
+
+	// KeyFunc maps a key of the secret at path to the key it is looked up
+	// with. By default keys are named like struct fields, so db-password and
+	// dbPassword are both read as DB_PASSWORD.
+	KeyFunc func(path string, key string) string
+
+	// CacheTTL is how long secrets are cached before they are read again on
+	// lookup. They are cached until Refresh is called when it is zero. When
+	// they cannot be read again, the previous secrets are kept for another
+	// CacheTTL, and LookupEnvChecked returns the error.
+	CacheTTL time.Duration
+
+	// Timeout is how long reading the secrets again on lookup may take, 10s
+	// by default
+	Timeout time.Duration
+
+	// HTTPClient is used for the requests, http.DefaultClient by default
+	HTTPClient *http.Client
+}
+
+// VaultReader reads secrets from the KV v2 secrets engine of HashiCorp Vault
+type VaultReader struct {
+	config VaultConfig
+
+	// refreshMu makes concurrent lookups of an expired cache read the
+	// secrets once
+	refreshMu sync.Mutex
+
+	mu            sync.Mutex
+	token         string
+	leaseDuration time.Duration
+	renewable     bool
+	values        MapReader
+	fetchedAt     time.Time
+}
+
+// NewVaultReader authenticates with Vault and reads the configured secrets
+func NewVaultReader(ctx context.Context, config VaultConfig) (*VaultReader, error) {
+	if config.Address == "" {
+		config.Address = os.Getenv("VAULT_ADDR")
+	}
+	if config.Token == "" {
+		config.Token = os.Getenv("VAULT_TOKEN")
+	}
+	if config.AppRoleMount == "" {
+		config.AppRoleMount = "approle"
+	}
+	if config.Mount == "" {
+		config.Mount = "secret"
+	}
+	if config.KeyFunc == nil {
+		config.KeyFunc = func(path string, key string) string {
+			return documentKey(key)
+		}
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	if config.Address == "" {
+		return nil, fmt.Errorf("vault address is not set")
+	}
+	config.Address = strings.TrimSuffix(config.Address, "/")
+
+	r := &VaultReader{config: config, token: config.Token}
+
+	if r.token == "" {
+		if config.RoleID == "" {
+			return nil, fmt.Errorf("vault token or AppRole credentials are not set")
+		}
+
+		if err := r.login(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// LookupEnv returns the cached secret of the key, reading the secrets again
+// first when the cache has expired. The previous secrets are returned when
+// they cannot be read again.
+func (r *VaultReader) LookupEnv(key string) (string, bool) {
+	_ = r.refreshExpired()
+
+	return r.lookupCached(key)
+}
+
+// LookupEnvChecked looks the key up like LookupEnv, but fails when the
+// expired cache cannot be read again
+func (r *VaultReader) LookupEnvChecked(key string) (string, bool, error) {
+	if err := r.refreshExpired(); err != nil {
+		return "", false, err
+	}
+
+	value, ok := r.lookupCached(key)
+	return value, ok, nil
+}
+
+func (r *VaultReader) lookupCached(key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.values.LookupEnv(key)
+}
+
+func (r *VaultReader) refreshExpired() error {
+	if r.config.CacheTTL <= 0 {
+		return nil
+	}
+
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+
+	r.mu.Lock()
+	expired := time.Since(r.fetchedAt) > r.config.CacheTTL
+	r.mu.Unlock()
+
+	if !expired {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.Timeout)
+	defer cancel()
+
+	if err := r.Refresh(ctx); err != nil {
+		// the previous secrets are kept until the next try, so that every
+		// lookup does not wait for Vault
+		r.mu.Lock()
+		r.fetchedAt = time.Now()
+		r.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *VaultReader) Environ() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.values.Environ()
+}
+
+// Refresh reads the configured secrets again
+func (r *VaultReader) Refresh(ctx context.Context) error {
+	values := make(MapReader)
+
+	for _, path := range r.config.Paths {
+		var response struct {
+			Data struct {
+				Data map[string]any `json:"data"`
+			} `json:"data"`
+		}
+
+		url := fmt.Sprintf("%s/v1/%s/data/%s", r.config.Address, r.config.Mount, strings.TrimPrefix(path, "/"))
+		if err := r.do(ctx, http.MethodGet, url, nil, &response); err != nil {
+			return fmt.Errorf("failed to read vault secret %s: %w", path, err)
+		}
+
+		for key, value := range response.Data.Data {
+			scalar, ok := formatScalar(value)
+			if !ok {
+				encoded, err := json.Marshal(value)
+				if err != nil {
+					return err
+				}
+				scalar = string(encoded)
+			}
+
+			values[r.config.KeyFunc(path, key)] = scalar
+		}
+	}
+
+	r.mu.Lock()
+	r.values = values
+	r.fetchedAt = time.Now()
+	r.mu.Unlock()
+
+	return nil
+}
+
+// RenewToken extends the lease of the token. Tokens obtained with AppRole are
+// replaced by logging in again once they cannot be renewed anymore.
+func (r *VaultReader) RenewToken(ctx context.Context) error {
+	r.mu.Lock()
+	renewable := r.renewable
+	r.mu.Unlock()
+
+	if !renewable && r.config.RoleID != "" {
+		return r.login(ctx)
+	}
+
+	var response vaultAuthResponse
+	err := r.do(ctx, http.MethodPost, r.config.Address+"/v1/auth/token/renew-self", struct{}{}, &response)
+	if err != nil && r.config.RoleID != "" {
+		return r.login(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to renew vault token: %w", err)
+	}
+
+	r.setAuth(response)
+	return nil
+}
+
+// KeepAlive renews the token when two thirds of its lease have passed, until
+// the context is done or renewing fails
+func (r *VaultReader) KeepAlive(ctx context.Context) error {
+	for {
+		r.mu.Lock()
+		leaseDuration := r.leaseDuration
+		r.mu.Unlock()
+
+		if leaseDuration <= 0 {
+			leaseDuration = time.Hour
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(leaseDuration * 2 / 3):
+		}
+
+		if err := r.RenewToken(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+type vaultAuthResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+	} `json:"auth"`
+}
+
+func (r *VaultReader) login(ctx context.Context) error {
+	body := map[string]string{
+		"role_id":   r.config.RoleID,
+		"secret_id": r.config.SecretID,
+	}
+
+	var response vaultAuthResponse
+	url := fmt.Sprintf("%s/v1/auth/%s/login", r.config.Address, r.config.AppRoleMount)
+	if err := r.do(ctx, http.MethodPost, url, body, &response); err != nil {
+		return fmt.Errorf("failed to log in to vault with AppRole: %w", err)
+	}
+
+	r.setAuth(response)
+	return nil
+}
+
+func (r *VaultReader) setAuth(response vaultAuthResponse) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if response.Auth.ClientToken != "" {
+		r.token = response.Auth.ClientToken
+	}
+	r.leaseDuration = time.Duration(response.Auth.LeaseDuration) * time.Second
+	r.renewable = response.Auth.Renewable
+}
+
+func (r *VaultReader) do(ctx context.Context, method string, url string, body any, result any) error {
+	var requestBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(encoded)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, requestBody)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	token := r.token
+	r.mu.Unlock()
+
+	if token != "" {
+		request.Header.Set("X-Vault-Token", token)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := r.config.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		var vaultError struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(response.Body).Decode(&vaultError)
+
+		return fmt.Errorf("vault responded with %s: %s", response.Status, strings.Join(vaultError.Errors, ", "))
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	decoder.UseNumber()
+
+	return decoder.Decode(result)
+}
@@ -0,0 +1,191 @@
+package goenv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metinorak/goenv/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newVaultServer stands in for Vault with an AppRole login, a token renewal
+// endpoint and the secret/app/database secret
+func newVaultServer(t *testing.T, reads *int32) *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/auth/approle/login", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		if body["role_id"] != "role" || body["secret_id"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["invalid role or secret ID"]}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"approle-token","lease_duration":3600,"renewable":true}}`))
+	})
+
+	mux.HandleFunc("/v1/auth/token/renew-self", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"` + r.Header.Get("X-Vault-Token") + `","lease_duration":7200,"renewable":true}}`))
+	})
+
+	mux.HandleFunc("/v1/secret/data/app/database", func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("X-Vault-Token"); token != "root-token" && token != "approle-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		atomic.AddInt32(reads, 1)
+		_, _ = w.Write([]byte(`{"data":{"data":{"password":"secret","max-conns":10},"metadata":{"version":3}}}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestVaultReader(t *testing.T) {
+	t.Run("TestVaultReader_WithToken", func(t *testing.T) {
+		var reads int32
+		server := newVaultServer(t, &reads)
+
+		reader, err := NewVaultReader(context.Background(), VaultConfig{
+			Address: server.URL,
+			Token:   "root-token",
+			Paths:   []string{"app/database"},
+		})
+		require.NoError(t, err)
+
+		value, ok := reader.LookupEnv("PASSWORD")
+		assert.True(t, ok)
+		assert.Equal(t, "secret", value)
+
+		assert.Equal(t, []string{"MAX_CONNS=10", "PASSWORD=secret"}, reader.Environ())
+
+		// secrets are cached until they are refreshed
+		reader.LookupEnv("PASSWORD")
+		assert.Equal(t, int32(1), atomic.LoadInt32(&reads))
+
+		require.NoError(t, reader.Refresh(context.Background()))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&reads))
+	})
+
+	t.Run("TestVaultReader_WithAppRoleAndRenewal", func(t *testing.T) {
+		var reads int32
+		server := newVaultServer(t, &reads)
+
+		reader, err := NewVaultReader(context.Background(), VaultConfig{
+			Address:  server.URL,
+			RoleID:   "role",
+			SecretID: "secret",
+			Paths:    []string{"app/database"},
+			KeyFunc: func(path string, key string) string {
+				return "DATABASE_" + documentKey(key)
+			},
+			CacheTTL: time.Nanosecond,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, reader.leaseDuration)
+
+		require.NoError(t, reader.RenewToken(context.Background()))
+		assert.Equal(t, 2*time.Hour, reader.leaseDuration)
+		assert.Equal(t, "approle-token", reader.token)
+
+		// Create mock EnvReader
+		mockEnvReader := mocks.NewMockEnvReader(gomock.NewController(t))
+
+		// Set the expected values for the mock
+		mockEnvReader.EXPECT().LookupEnv(gomock.Any()).Return("", false).AnyTimes()
+
+		loader, err := NewLoader(WithReader(NewChainReader(mockEnvReader, reader)))
+		require.NoError(t, err)
+
+		config := &struct {
+			Database struct {
+				Password string
+				MaxConns int
+			}
+		}{}
+
+		err = loader.Load(config)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "secret", config.Database.Password)
+		assert.Equal(t, 10, config.Database.MaxConns)
+
+		// an expired cache is read again on lookup
+		assert.Greater(t, atomic.LoadInt32(&reads), int32(1))
+	})
+
+	t.Run("TestVaultReader_WhenRefreshOnLookupFails", func(t *testing.T) {
+		var reads int32
+		server := newVaultServer(t, &reads)
+
+		reader, err := NewVaultReader(context.Background(), VaultConfig{
+			Address:  server.URL,
+			Token:    "root-token",
+			Paths:    []string{"app/database"},
+			CacheTTL: time.Hour,
+		})
+		require.NoError(t, err)
+
+		// expire the cache with a token Vault rejects
+		reader.token = "other-token"
+		reader.fetchedAt = time.Now().Add(-2 * time.Hour)
+
+		// plain lookups keep returning the previous secrets
+		value, ok := reader.LookupEnv("PASSWORD")
+		assert.True(t, ok)
+		assert.Equal(t, "secret", value)
+
+		reader.fetchedAt = time.Now().Add(-2 * time.Hour)
+
+		loader, err := NewLoader(WithReader(reader))
+		require.NoError(t, err)
+
+		err = loader.Load(&struct{ Password string }{})
+		assert.ErrorContains(t, err, "permission denied")
+
+		// the previous secrets are kept until the cache expires again
+		value, ok, err = reader.LookupEnvChecked("PASSWORD")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "secret", value)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&reads))
+	})
+
+	t.Run("TestVaultReader_WhenLoginFails", func(t *testing.T) {
+		var reads int32
+		server := newVaultServer(t, &reads)
+
+		_, err := NewVaultReader(context.Background(), VaultConfig{
+			Address:  server.URL,
+			RoleID:   "role",
+			SecretID: "wrong",
+			Paths:    []string{"app/database"},
+		})
+		assert.ErrorContains(t, err, "invalid role or secret ID")
+	})
+
+	t.Run("TestVaultReader_WhenTokenIsNotPermitted", func(t *testing.T) {
+		var reads int32
+		server := newVaultServer(t, &reads)
+
+		_, err := NewVaultReader(context.Background(), VaultConfig{
+			Address: server.URL,
+			Token:   "other-token",
+			Paths:   []string{"app/database"},
+		})
+		assert.ErrorContains(t, err, "permission denied")
+	})
+}